@@ -2,9 +2,4 @@ module github.com/chinagocoder/rongCloud-sdk
 
 go 1.19
 
-require (
-	github.com/astaxie/beego v1.12.3
-	github.com/google/uuid v1.3.0
-)
-
-require gopkg.in/yaml.v2 v2.2.8 // indirect
+require github.com/google/uuid v1.3.0
@@ -0,0 +1,215 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DEFAULT_AUDIT_TIMEOUT 消息回调默认的处理时间
+	DEFAULT_AUDIT_TIMEOUT = 2 * time.Second
+)
+
+// AuditAction 消息回调规则的处理结果
+type AuditAction int
+
+const (
+	// AuditPass 放行，继续执行后面的规则
+	AuditPass AuditAction = iota
+	// AuditReplace 替换消息内容后放行，继续执行后面的规则，后面的规则看到的是替换后的内容
+	AuditReplace
+	// AuditBlock 拦截消息，不再执行后面的规则
+	AuditBlock
+)
+
+// AuditMessage 消息回调推送的一条待下发消息
+type AuditMessage struct {
+	FromUserID     string      `json:"fromUserId"`
+	TargetID       string      `json:"targetId"`
+	ToUserIDs      []string    `json:"toUserIds"`
+	ObjectName     string      `json:"msgType"`
+	Content        string      `json:"content"` // 消息内容原文，JSON 字符串
+	PushContent    string      `json:"pushContent"`
+	DisablePush    bool        `json:"disablePush"`
+	PushExt        string      `json:"pushExt"`
+	Expansion      bool        `json:"expansion"`
+	ExtraContent   string      `json:"extraContent"`
+	ChannelType    ChannelType `json:"channelType"`
+	MsgTimestamp   int64       `json:"msgTimeStamp"` // 毫秒
+	MessageID      string      `json:"messageId"`
+	OriginalMsgUID string      `json:"originalMsgUID"`
+	OS             string      `json:"os"`
+	BusChannel     string      `json:"busChannel"`
+	ClientIP       string      `json:"clientIp"`
+
+	// Message 按 objectName 解析后的消息，规则修改 Message 后返回 AuditReplace 即可替换消息内容
+	// 未通过 RegisterMessage 注册或解析失败时为 nil，规则可以直接修改 Content
+	Message rcMsg `json:"-"`
+}
+
+// auditMessageJSON msgTimeStamp 可能是字符串，content 可能是对象
+type auditMessageJSON struct {
+	AuditMessage
+	Content      json.RawMessage `json:"content"`
+	MsgTimestamp json.Number     `json:"msgTimeStamp"`
+}
+
+// ParseAuditMessage 解析消息回调请求
+func ParseAuditMessage(r *http.Request) (*AuditMessage, error) {
+	var raw auditMessageJSON
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+	msg := raw.AuditMessage
+	var err error
+	if msg.Content, err = rawContent(raw.Content); err != nil {
+		return nil, err
+	}
+	if raw.MsgTimestamp != "" {
+		if msg.MsgTimestamp, err = raw.MsgTimestamp.Int64(); err != nil {
+			return nil, fmt.Errorf("invalid msgTimeStamp %s", raw.MsgTimestamp)
+		}
+	}
+	msg.Message = decodeMessageContent(msg.ObjectName, msg.Content)
+	return &msg, nil
+}
+
+// AuditRule 消息回调规则，返回错误时按 WithAuditFailOpen 的设置放行或拦截
+type AuditRule func(ctx context.Context, msg *AuditMessage) (AuditAction, error)
+
+// AuditResponse 消息回调的应答
+type AuditResponse struct {
+	Pass           int    `json:"pass"` // 1 放行，0 拦截
+	ReplaceContent string `json:"replaceContent,omitempty"`
+	PushContent    string `json:"pushContent,omitempty"`
+}
+
+// AuditOption AuditHandler 的配置项
+type AuditOption func(*AuditHandler)
+
+// WithAuditTimeout 设置规则执行的最长时间，默认 DEFAULT_AUDIT_TIMEOUT，应小于融云等待应答的时间
+func WithAuditTimeout(timeout time.Duration) AuditOption {
+	return func(h *AuditHandler) {
+		h.timeout = timeout
+	}
+}
+
+// WithAuditFailOpen 设置规则超时、出错或 panic 时是否放行，默认放行原消息
+func WithAuditFailOpen(failOpen bool) AuditOption {
+	return func(h *AuditHandler) {
+		h.failOpen = failOpen
+	}
+}
+
+// AuditHandler 消息回调的 http.Handler，按顺序执行规则并返回放行、拦截或替换的应答
+type AuditHandler struct {
+	verifier *WebhookVerifier
+	rules    []AuditRule
+	timeout  time.Duration
+	failOpen bool
+}
+
+// NewAuditHandler 创建消息回调处理器，verifier 不为 nil 时先校验签名，校验失败响应 401
+func NewAuditHandler(verifier *WebhookVerifier, rules []AuditRule, options ...AuditOption) *AuditHandler {
+	h := &AuditHandler{
+		verifier: verifier,
+		rules:    rules,
+		timeout:  DEFAULT_AUDIT_TIMEOUT,
+		failOpen: true,
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// Audit 按顺序对消息执行规则，在 ctx 结束前返回应答。超时返回后规则可能仍在执行，不应再读取 msg
+func (h *AuditHandler) Audit(ctx context.Context, msg *AuditMessage) AuditResponse {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+	done := make(chan AuditResponse, 1)
+	go func() {
+		resp, err := h.run(ctx, msg)
+		if err != nil {
+			resp = h.fail()
+		}
+		done <- resp
+	}()
+	select {
+	case resp := <-done:
+		return resp
+	case <-ctx.Done():
+		return h.fail()
+	}
+}
+
+// fail 规则超时或出错时的应答
+func (h *AuditHandler) fail() AuditResponse {
+	if h.failOpen {
+		return AuditResponse{Pass: 1}
+	}
+	return AuditResponse{Pass: 0}
+}
+
+// run 执行规则，规则 panic 时返回错误
+func (h *AuditHandler) run(ctx context.Context, msg *AuditMessage) (resp AuditResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("audit rule panic: %v", r)
+		}
+	}()
+	replaced := false
+	pushContent := msg.PushContent
+	for _, rule := range h.rules {
+		action, err := rule(ctx, msg)
+		if err != nil {
+			return AuditResponse{}, err
+		}
+		switch action {
+		case AuditBlock:
+			return AuditResponse{Pass: 0}, nil
+		case AuditReplace:
+			if msg.Message != nil {
+				if msg.Content, err = msg.Message.ToString(); err != nil {
+					return AuditResponse{}, err
+				}
+			}
+			replaced = true
+		}
+		if err := ctx.Err(); err != nil {
+			return AuditResponse{}, err
+		}
+	}
+	resp = AuditResponse{Pass: 1}
+	if replaced {
+		resp.ReplaceContent = msg.Content
+		if msg.PushContent != pushContent {
+			resp.PushContent = msg.PushContent
+		}
+	}
+	return resp, nil
+}
+
+// ServeHTTP 解析消息回调，执行规则并写入应答
+func (h *AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.verifier != nil {
+		if err := h.verifier.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	msg, err := ParseAuditMessage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := h.Audit(r.Context(), msg)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAuditMessage(t *testing.T) {
+	body := `{"fromUserId":"u01","targetId":"g01","toUserIds":["u02"],"msgType":"RC:TxtMsg","content":"{\"content\":\"hello\"}","channelType":"GROUP","msgTimeStamp":"1570000000000","messageId":"BD3A-0001"}`
+	msg, err := ParseAuditMessage(httptest.NewRequest(http.MethodPost, "/audit", strings.NewReader(body)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.FromUserID != "u01" || msg.TargetID != "g01" || msg.ChannelType != ChannelTypeGroup || msg.MsgTimestamp != 1570000000000 || msg.MessageID != "BD3A-0001" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	if txt, ok := msg.Message.(*TXTMsg); !ok || txt.Content != "hello" {
+		t.Errorf("unexpected content %#v", msg.Message)
+	}
+}
+
+func TestAuditHandler(t *testing.T) {
+	replace := func(ctx context.Context, msg *AuditMessage) (AuditAction, error) {
+		txt, ok := msg.Message.(*TXTMsg)
+		if !ok || !strings.Contains(txt.Content, "bad") {
+			return AuditPass, nil
+		}
+		txt.Content = strings.ReplaceAll(txt.Content, "bad", "***")
+		return AuditReplace, nil
+	}
+	block := func(ctx context.Context, msg *AuditMessage) (AuditAction, error) {
+		if txt, ok := msg.Message.(*TXTMsg); ok && strings.Contains(txt.Content, "spam") {
+			return AuditBlock, nil
+		}
+		return AuditPass, nil
+	}
+	h := NewAuditHandler(NewWebhookVerifier("key", "secret"), []AuditRule{replace, block})
+
+	serve := func(target, content string) (int, AuditResponse) {
+		body, _ := json.Marshal(map[string]string{"msgType": "RC:TxtMsg", "content": content})
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, target, strings.NewReader(string(body))))
+		var resp AuditResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+
+	cases := []struct {
+		content string
+		pass    int
+		replace string
+	}{
+		{`{"content":"hello"}`, 1, ""},
+		{`{"content":"a bad word"}`, 1, "a *** word"},
+		{`{"content":"bad spam"}`, 0, ""},
+	}
+	for i, c := range cases {
+		code, resp := serve(signedWebhookURL("key", "secret", "n"+string(rune('0'+i)), time.Now()), c.content)
+		if code != http.StatusOK || resp.Pass != c.pass {
+			t.Errorf("%s: unexpected response %d %+v", c.content, code, resp)
+			continue
+		}
+		var txt TXTMsg
+		if c.replace != "" && (json.Unmarshal([]byte(resp.ReplaceContent), &txt) != nil || txt.Content != c.replace) {
+			t.Errorf("%s: unexpected replace content %s", c.content, resp.ReplaceContent)
+		}
+		if c.replace == "" && resp.ReplaceContent != "" {
+			t.Errorf("%s: unexpected replace content %s", c.content, resp.ReplaceContent)
+		}
+	}
+	if code, _ := serve("/audit", `{"content":"hello"}`); code != http.StatusUnauthorized {
+		t.Errorf("expect 401 without signature, got %d", code)
+	}
+}
+
+func TestAuditHandler_fail(t *testing.T) {
+	slow := func(ctx context.Context, msg *AuditMessage) (AuditAction, error) {
+		<-ctx.Done()
+		return AuditPass, nil
+	}
+	failing := func(ctx context.Context, msg *AuditMessage) (AuditAction, error) {
+		return AuditPass, errors.New("rule failed")
+	}
+	panicking := func(ctx context.Context, msg *AuditMessage) (AuditAction, error) {
+		panic("rule panic")
+	}
+	for _, rule := range []AuditRule{slow, failing, panicking} {
+		msg := &AuditMessage{ObjectName: "RC:TxtMsg", Content: `{"content":"hello"}`}
+		open := NewAuditHandler(nil, []AuditRule{rule}, WithAuditTimeout(10*time.Millisecond))
+		if resp := open.Audit(context.Background(), msg); resp.Pass != 1 {
+			t.Errorf("fail open should pass, got %+v", resp)
+		}
+		closed := NewAuditHandler(nil, []AuditRule{rule}, WithAuditTimeout(10*time.Millisecond), WithAuditFailOpen(false))
+		if resp := closed.Audit(context.Background(), msg); resp.Pass != 0 {
+			t.Errorf("fail closed should block, got %+v", resp)
+		}
+	}
+}
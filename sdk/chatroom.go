@@ -5,10 +5,8 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // ChatRoomInfo 聊天室信息
@@ -192,8 +190,7 @@ func (rc *RongCloud) ChatUserExistResObj(chatroomId, userId string) (ChatUserExi
 	if len(userId) == 0 {
 		return result, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/exist.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatUserExistResObj", http.MethodPost, "/chatroom/user/exist.json")
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatroomId)
@@ -224,8 +221,7 @@ func (rc *RongCloud) ChatUserExist(chatroomId, userId string) ([]byte, error) {
 	if len(userId) == 0 {
 		return nil, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/exist.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatUserExist", http.MethodPost, "/chatroom/user/exist.json")
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatroomId)
@@ -253,8 +249,7 @@ func (rc *RongCloud) ChatRoomCreate(id, name string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/create." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomCreate", http.MethodPost, "/chatroom/create."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroom["+id+"]", name)
@@ -274,8 +269,7 @@ func (rc *RongCloud) ChatRoomCreateNew(chatroomId string, options ...ChatroomOpt
 
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/create_new." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomCreateNew", http.MethodPost, "/chatroom/create_new."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatroomId)
@@ -283,8 +277,6 @@ func (rc *RongCloud) ChatRoomCreateNew(chatroomId string, options ...ChatroomOpt
 	req.Param("destroyTime", strconv.Itoa(extOptions.destroyTime))
 	req.Param("isBan", strconv.FormatBool(extOptions.isBan))
 
-	fmt.Println(strconv.FormatBool(extOptions.isBan))
-
 	for _, v := range extOptions.whiteUserIds {
 		req.Param("whiteUserIds", v)
 	}
@@ -314,8 +306,7 @@ func (rc *RongCloud) ChatRoomDestroySet(chatroomId string, destroyType, destroyT
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/destroy/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDestroySet", http.MethodPost, "/chatroom/destroy/set."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatroomId)
@@ -335,8 +326,7 @@ func (rc *RongCloud) ChatRoomGetNew(chatroomId string) (ChatRoomGetResult, error
 		return ChatRoomGetResult{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/get." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomGetNew", http.MethodPost, "/chatroom/get."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", chatroomId)
 
@@ -345,7 +335,6 @@ func (rc *RongCloud) ChatRoomGetNew(chatroomId string) (ChatRoomGetResult, error
 		rc.urlError(err)
 		return ChatRoomGetResult{}, err
 	}
-	fmt.Println(string(resp))
 	var dat ChatRoomGetResult
 	if err := json.Unmarshal(resp, &dat); err != nil {
 		return ChatRoomGetResult{}, err
@@ -367,8 +356,7 @@ func (rc *RongCloud) ChatRoomEntryBatchSet(chatroomId string, autoDelete int, en
 		return RCErrorNew(1002, "Paramer 'entryInfo' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/batch/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomEntryBatchSet", http.MethodPost, "/chatroom/entry/batch/set."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatroomId)
@@ -401,8 +389,7 @@ func (rc *RongCloud) ChatRoomDestroy(id string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/destroy." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDestroy", http.MethodPost, "/chatroom/destroy."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", id)
@@ -435,8 +422,7 @@ func (rc *RongCloud) ChatRoomGet(id string, count, order int) (ChatRoomResult, e
 		return ChatRoomResult{}, RCErrorNew(1002, "Paramer 'order' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomGet", http.MethodPost, "/chatroom/user/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 	req.Param("count", strconv.Itoa(count))
@@ -470,8 +456,7 @@ func (rc *RongCloud) ChatRoomIsExist(id string, members []string) ([]ChatRoomUse
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'count' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/users/exist." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomIsExist", http.MethodPost, "/chatroom/users/exist."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 	for _, v := range members {
@@ -513,8 +498,7 @@ func (rc *RongCloud) ChatRoomBlockAdd(id string, members []string, minute uint,
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBlockAdd", http.MethodPost, "/chatroom/user/block/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 	for _, v := range members {
@@ -552,8 +536,7 @@ func (rc *RongCloud) ChatRoomBlockRemove(id string, members []string, options ..
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBlockRemove", http.MethodPost, "/chatroom/user/block/rollback."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -583,8 +566,7 @@ func (rc *RongCloud) ChatRoomBlockGetList(id string) (ChatRoomResult, error) {
 		return dat, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBlockGetList", http.MethodPost, "/chatroom/user/block/list."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -616,8 +598,7 @@ func (rc *RongCloud) ChatRoomBanAdd(members []string, minute uint, options ...Ch
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanAdd", http.MethodPost, "/chatroom/user/ban/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -648,8 +629,7 @@ func (rc *RongCloud) ChatRoomBanRemove(members []string, options ...ChatroomOpti
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanRemove", http.MethodPost, "/chatroom/user/ban/remove."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -672,8 +652,7 @@ func (rc *RongCloud) ChatRoomBanRemove(members []string, options ...ChatroomOpti
  */
 func (rc *RongCloud) ChatRoomBanGetList() ([]ChatRoomUser, error) {
 	var dat ChatRoomResult
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanGetList", http.MethodPost, "/chatroom/user/ban/query."+ReqType)
 	rc.fillHeader(req)
 
 	resp, err := rc.do(req)
@@ -710,8 +689,7 @@ func (rc *RongCloud) ChatRoomGagAdd(id string, members []string, minute uint, op
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomGagAdd", http.MethodPost, "/chatroom/user/gag/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -747,8 +725,7 @@ func (rc *RongCloud) ChatRoomGagRemove(id string, members []string, options ...C
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomGagRemove", http.MethodPost, "/chatroom/user/gag/rollback."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -778,8 +755,7 @@ func (rc *RongCloud) ChatRoomGagGetList(id string) ([]ChatRoomUser, error) {
 	if id == "" {
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomGagGetList", http.MethodPost, "/chatroom/user/gag/list."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -804,8 +780,7 @@ func (rc *RongCloud) ChatRoomDemotionAdd(objectNames []string) error {
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDemotionAdd", http.MethodPost, "/chatroom/message/priority/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range objectNames {
 		req.Param("objectName", v)
@@ -828,8 +803,7 @@ func (rc *RongCloud) ChatRoomDemotionRemove(objectNames []string) error {
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDemotionRemove", http.MethodPost, "/chatroom/message/priority/remove."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range objectNames {
 		req.Param("objectName", v)
@@ -849,8 +823,7 @@ func (rc *RongCloud) ChatRoomDemotionRemove(objectNames []string) error {
 func (rc *RongCloud) ChatRoomDemotionGetList() ([]string, error) {
 	var dat ChatRoomResult
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDemotionGetList", http.MethodPost, "/chatroom/message/priority/query."+ReqType)
 	rc.fillHeader(req)
 
 	resp, err := rc.do(req)
@@ -874,8 +847,7 @@ func (rc *RongCloud) ChatRoomDistributionStop(id string) error {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/stopDistribution." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDistributionStop", http.MethodPost, "/chatroom/message/stopDistribution."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -895,8 +867,7 @@ func (rc *RongCloud) ChatRoomDistributionResume(id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/resumeDistribution." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomDistributionResume", http.MethodPost, "/chatroom/message/resumeDistribution."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -916,8 +887,7 @@ func (rc *RongCloud) ChatRoomKeepAliveAdd(id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomKeepAliveAdd", http.MethodPost, "/chatroom/keepalive/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -937,8 +907,7 @@ func (rc *RongCloud) ChatRoomKeepAliveRemove(id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomKeepAliveRemove", http.MethodPost, "/chatroom/keepalive/remove."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
@@ -959,8 +928,7 @@ func (rc *RongCloud) ChatRoomKeepAliveGetList() ([]string, error) {
 	// if id == "" {
 	// 	return []string{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	// }
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomKeepAliveGetList", http.MethodPost, "/chatroom/keepalive/query."+ReqType)
 	rc.fillHeader(req)
 	// req.Param("chatroomId", id)
 
@@ -986,8 +954,7 @@ func (rc *RongCloud) ChatRoomWhitelistAdd(objectNames []string) error {
 		return RCErrorNew(1002, "Paramer 'objectNames' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomWhitelistAdd", http.MethodPost, "/chatroom/whitelist/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range objectNames {
 		req.Param("objectnames", v)
@@ -1011,8 +978,7 @@ func (rc *RongCloud) ChatRoomWhitelistRemove(objectNames []string) error {
 		return RCErrorNew(1002, "Paramer 'objectNames' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomWhitelistRemove", http.MethodPost, "/chatroom/whitelist/delete."+ReqType)
 	rc.fillHeader(req)
 
 	for _, v := range objectNames {
@@ -1033,8 +999,7 @@ func (rc *RongCloud) ChatRoomWhitelistRemove(objectNames []string) error {
 func (rc *RongCloud) ChatRoomWhitelistGetList() ([]string, error) {
 	var dat ChatRoomResult
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomWhitelistGetList", http.MethodPost, "/chatroom/whitelist/query."+ReqType)
 	rc.fillHeader(req)
 
 	resp, err := rc.do(req)
@@ -1064,8 +1029,7 @@ func (rc *RongCloud) ChatRoomUserWhitelistAdd(id string, members []string) error
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserWhitelistAdd", http.MethodPost, "/chatroom/user/whitelist/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 	for _, v := range members {
@@ -1094,8 +1058,7 @@ func (rc *RongCloud) ChatRoomUserWhitelistRemove(id string, members []string) er
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserWhitelistRemove", http.MethodPost, "/chatroom/user/whitelist/remove."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 	for _, v := range members {
@@ -1119,19 +1082,11 @@ func (rc *RongCloud) ChatRoomUserWhitelistGetList(id string) ([]string, error) {
 	if id == "" {
 		return []string{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserWhitelistGetList", http.MethodPost, "/chatroom/user/whitelist/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
-	response, err := req.Response()
-	if err != nil {
-		return []string{}, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
@@ -1179,8 +1134,7 @@ func (rc *RongCloud) ChatRoomMuteMembersAdd(id string, members []string, minute
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomMuteMembersAdd", http.MethodPost, "/chatroom/user/gag/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -1211,19 +1165,11 @@ func (rc *RongCloud) ChatRoomMuteMembersGetList(id string) ([]ChatRoomUser, erro
 	if id == "" {
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomMuteMembersGetList", http.MethodPost, "/chatroom/user/gag/list."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", id)
 
-	response, err := req.Response()
-	if err != nil {
-		return []ChatRoomUser{}, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(req)
 	if err != nil {
 		rc.urlError(err)
 		return []ChatRoomUser{}, err
@@ -1258,8 +1204,7 @@ func (rc *RongCloud) ChatRoomMuteMembersRemove(id string, members []string, opti
 	}
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomMuteMembersRemove", http.MethodPost, "/chatroom/user/gag/rollback."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -1305,8 +1250,7 @@ func (rc *RongCloud) ChatRoomEntrySet(chatRoomID, userID, key, value string, aut
 		return RCErrorNew(1002, "Paramer 'value' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomEntrySet", http.MethodPost, "/chatroom/entry/set."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatRoomID)
@@ -1344,8 +1288,7 @@ func (rc *RongCloud) ChatRoomEntryRemove(chatRoomID, userID, key string) error {
 		return RCErrorNew(1002, "Paramer 'key' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomEntryRemove", http.MethodPost, "/chatroom/entry/remove."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatRoomID)
@@ -1376,8 +1319,7 @@ func (rc *RongCloud) ChatRoomEntryQuery(chatRoomID string, keys ...string) ([]Ch
 		return nil, RCErrorNew(1002, "Paramer 'keys' more than 100")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomEntryQuery", http.MethodPost, "/chatroom/entry/query."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("chatroomId", chatRoomID)
@@ -1411,9 +1353,8 @@ func (rc *RongCloud) ChatRoomQuery(chatRoomID []string) ([]ChatRoom, error) {
 		return nil, RCErrorNew(1002, "Paramer 'chatRoomID' is required")
 	}
 
-	url := fmt.Sprintf(`%s/chatroom/query.%s`, rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf(`/chatroom/query.%s`, ReqType)
+	req := rc.newRequest("ChatRoomQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	for _, v := range chatRoomID {
@@ -1442,8 +1383,7 @@ func (rc *RongCloud) ChatRoomBan(chatroomId string, options ...ChatroomOption) e
 
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/ban/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBan", http.MethodPost, "/chatroom/ban/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", chatroomId)
 	if extOptions.needNotify {
@@ -1466,8 +1406,7 @@ func (rc *RongCloud) ChatRoomBanRollback(chatroomId string, options ...ChatroomO
 
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/ban/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanRollback", http.MethodPost, "/chatroom/ban/rollback."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", chatroomId)
 	if extOptions.needNotify {
@@ -1484,8 +1423,7 @@ func (rc *RongCloud) ChatRoomBanRollback(chatroomId string, options ...ChatroomO
 
 // 查询聊天室全体禁言列表
 func (rc *RongCloud) ChatRoomBanQuery(size, page int) ([]string, error) {
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/ban/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanQuery", http.MethodPost, "/chatroom/ban/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("page", strconv.Itoa(page))
 	req.Param("size", strconv.Itoa(size))
@@ -1508,8 +1446,7 @@ func (rc *RongCloud) ChatRoomBanCheck(chatroomId string) (bool, error) {
 		return false, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/ban/check." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomBanCheck", http.MethodPost, "/chatroom/ban/check."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", chatroomId)
 
@@ -1546,8 +1483,7 @@ func (rc *RongCloud) ChatRoomUserBanWhitelistAdd(chatroomId string, members []st
 
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserBanWhitelistAdd", http.MethodPost, "/chatroom/user/ban/whitelist/add."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -1577,8 +1513,7 @@ func (rc *RongCloud) ChatRoomUserBanWhitelistRollback(chatroomId string, members
 
 	extOptions := modifyChatroomOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/whitelist/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserBanWhitelistRollback", http.MethodPost, "/chatroom/user/ban/whitelist/rollback."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range members {
 		req.Param("userId", v)
@@ -1602,8 +1537,7 @@ func (rc *RongCloud) ChatRoomUserBanWhitelistQuery(chatroomId string) ([]string,
 		return []string{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomUserBanWhitelistQuery", http.MethodPost, "/chatroom/user/ban/whitelist/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("chatroomId", chatroomId)
 
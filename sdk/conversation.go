@@ -7,9 +7,6 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // ConversationType 会话类型
@@ -59,8 +56,7 @@ func (rc *RongCloud) ConversationTop(conversationType ConversationType, userId,
 		return RCErrorNew(1002, "Paramer 'setTop' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/top/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ConversationTop", http.MethodPost, "/conversation/top/set."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
@@ -98,8 +94,7 @@ func (rc *RongCloud) ConversationMute(conversationType ConversationType, userID,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ConversationMute", http.MethodPost, "/conversation/notification/set."+ReqType)
 	rc.fillHeader(req)
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
@@ -141,8 +136,7 @@ func (rc *RongCloud) ConversationUnmute(conversationType ConversationType, userI
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ConversationUnmute", http.MethodPost, "/conversation/notification/set."+ReqType)
 	rc.fillHeader(req)
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
@@ -184,8 +178,7 @@ func (rc *RongCloud) ConversationGet(conversationType ConversationType, userID,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/get." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ConversationGet", http.MethodPost, "/conversation/notification/get."+ReqType)
 	rc.fillHeader(req)
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
@@ -195,14 +188,7 @@ func (rc *RongCloud) ConversationGet(conversationType ConversationType, userID,
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	response, err := req.Response()
-	if err != nil {
-		return -1, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(req)
 	if err != nil {
 		rc.urlError(err)
 		return -1, err
@@ -237,12 +223,11 @@ func (rc *RongCloud) ConversationTypeNotificationSet(ct ConversationType, reques
 		return RCErrorNew(1002, "Paramer 'unPushLevel' was wrong")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/type/notification/set.json")
+	req := rc.newRequest("ConversationTypeNotificationSet", http.MethodPost, "/conversation/type/notification/set.json")
 
 	req.Param("conversationType", strconv.Itoa(int(ct)))
 	req.Param("requestId", requestId)
 	req.Param("unpushLevel", strconv.Itoa(unPushLevel))
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
 
 	rc.fillHeader(req)
 
@@ -259,7 +244,7 @@ func (rc *RongCloud) ConversationTypeNotificationSet(ct ConversationType, reques
 	}
 
 	if resp.Code != http.StatusOK {
-		return fmt.Errorf("Response error. code: %d", resp.Code)
+		return RCErrorNew(resp.Code, "Response error")
 	}
 
 	return nil
@@ -275,12 +260,11 @@ func (rc *RongCloud) ConversationTypeNotificationGet(ct ConversationType, reques
 		return 0, RCErrorNew(1002, "Paramer 'requestId' was wrong")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/type/notification/get.json")
+	req := rc.newRequest("ConversationTypeNotificationGet", http.MethodPost, "/conversation/type/notification/get.json")
 
 	req.Param("conversationType", strconv.Itoa(int(ct)))
 	req.Param("requestId", requestId)
 
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
 
 	rc.fillHeader(req)
 
@@ -298,7 +282,7 @@ func (rc *RongCloud) ConversationTypeNotificationGet(ct ConversationType, reques
 	}
 
 	if resp.Code != http.StatusOK {
-		return 0, fmt.Errorf("Response error. code: %d", resp.Code)
+		return 0, RCErrorNew(resp.Code, "Response error")
 	}
 
 	return resp.IsMuted, nil
@@ -327,7 +311,7 @@ func (rc *RongCloud) ConversationNotificationSet(ct ConversationType, requestId,
 		return RCErrorNew(1002, "Paramer 'unPushLevel' was wrong")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/set.json")
+	req := rc.newRequest("ConversationNotificationSet", http.MethodPost, "/conversation/notification/set.json")
 
 	req.Param("conversationType", strconv.Itoa(int(ct)))
 	req.Param("requestId", requestId)
@@ -339,7 +323,6 @@ func (rc *RongCloud) ConversationNotificationSet(ct ConversationType, requestId,
 		req.Param("busChannel", busChannel)
 	}
 
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
 
 	rc.fillHeader(req)
 
@@ -356,7 +339,7 @@ func (rc *RongCloud) ConversationNotificationSet(ct ConversationType, requestId,
 	}
 
 	if resp.Code != http.StatusOK {
-		return fmt.Errorf("Response error. code: %d", resp.Code)
+		return RCErrorNew(resp.Code, "Response error")
 	}
 
 	return nil
@@ -376,7 +359,7 @@ func (rc *RongCloud) ConversationNotificationGet(ct ConversationType, requestId,
 		return 0, RCErrorNew(1002, "Paramer 'targetId' was wrong")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/get.json")
+	req := rc.newRequest("ConversationNotificationGet", http.MethodPost, "/conversation/notification/get.json")
 
 	req.Param("conversationType", strconv.Itoa(int(ct)))
 	req.Param("requestId", requestId)
@@ -386,7 +369,6 @@ func (rc *RongCloud) ConversationNotificationGet(ct ConversationType, requestId,
 		req.Param("busChannel", busChannel)
 	}
 
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
 
 	rc.fillHeader(req)
 
@@ -404,7 +386,7 @@ func (rc *RongCloud) ConversationNotificationGet(ct ConversationType, requestId,
 	}
 
 	if resp.Code != http.StatusOK {
-		return 0, fmt.Errorf("Response error. code: %d", resp.Code)
+		return 0, RCErrorNew(resp.Code, "Response error")
 	}
 
 	return resp.IsMuted, nil
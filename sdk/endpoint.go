@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DEFAULT_ENDPOINT_MAX_FAILURES 域名连续失败多少次后暂停使用
+	DEFAULT_ENDPOINT_MAX_FAILURES = 1
+)
+
+// EndpointState Api 地址的健康状态
+type EndpointState struct {
+	URI           string    `json:"uri"`
+	Active        bool      `json:"active"`        // 当前请求是否使用该地址
+	Healthy       bool      `json:"healthy"`       // 是否可用，不可用的地址在冷却结束并探测成功后恢复
+	Failures      int       `json:"failures"`      // 连续失败次数
+	TotalFailures uint64    `json:"totalFailures"` // 累计失败次数
+	LastFailure   time.Time `json:"lastFailure"`   // 最后一次失败时间
+	CooldownUntil time.Time `json:"cooldownUntil"` // 冷却结束时间，可用时为零值
+}
+
+// EndpointProbe 探测 Api 地址是否恢复，返回 nil 表示可用
+type EndpointProbe func(ctx context.Context, uri string) error
+
+type endpoint struct {
+	uri           string
+	failures      int
+	totalFailures uint64
+	lastFailure   time.Time
+	downUntil     time.Time
+	probing       bool
+}
+
+// endpointPool Api 地址池
+// 按配置顺序优先使用可用的地址，连续失败达到 maxFailures 次的地址暂停使用 cooldown 时间，
+// 冷却结束后先探测，探测成功才重新使用
+type endpointPool struct {
+	mu           sync.Mutex
+	endpoints    []*endpoint
+	maxFailures  int
+	cooldown     time.Duration
+	probe        EndpointProbe
+	probeTimeout time.Duration
+	logger       Logger
+	metrics      Metrics
+	now          func() time.Time            // 当前时间，测试时可以替换
+	probeDone    func(uri string, err error) // 探测结束后调用，用于测试
+}
+
+func newEndpointPool(uris []string, maxFailures int, cooldown time.Duration, probe EndpointProbe, probeTimeout time.Duration) *endpointPool {
+	if maxFailures <= 0 {
+		maxFailures = DEFAULT_ENDPOINT_MAX_FAILURES
+	}
+	p := &endpointPool{
+		maxFailures:  maxFailures,
+		cooldown:     cooldown,
+		probe:        probe,
+		probeTimeout: probeTimeout,
+		logger:       nopLogger{},
+		metrics:      nopMetrics{},
+		now:          time.Now,
+	}
+	p.reset(uris)
+	return p
+}
+
+// reset 替换地址列表，健康状态清零
+func (p *endpointPool) reset(uris []string) {
+	endpoints := make([]*endpoint, 0, len(uris))
+	for _, uri := range uris {
+		endpoints = append(endpoints, &endpoint{uri: uri})
+	}
+	p.mu.Lock()
+	p.endpoints = endpoints
+	p.mu.Unlock()
+}
+
+// active 当前应使用的地址，调用方需持有锁
+// 优先返回第一个可用的地址；全部不可用时返回最早结束冷却的地址
+func (p *endpointPool) active() *endpoint {
+	var fallback *endpoint
+	for _, e := range p.endpoints {
+		if e.downUntil.IsZero() {
+			return e
+		}
+		if fallback == nil || e.downUntil.Before(fallback.downUntil) {
+			fallback = e
+		}
+	}
+	return fallback
+}
+
+// pick 获取本次请求使用的地址，同时对冷却结束的地址发起探测
+func (p *endpointPool) pick() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, e := range p.endpoints {
+		if e.downUntil.IsZero() || e.probing || now.Before(e.downUntil) {
+			continue
+		}
+		if p.probe == nil {
+			e.downUntil = time.Time{}
+			continue
+		}
+		e.probing = true
+		go p.doProbe(e)
+	}
+	if e := p.active(); e != nil {
+		return e.uri
+	}
+	return ""
+}
+
+func (p *endpointPool) doProbe(e *endpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.probeTimeout)
+	err := p.probe(ctx, e.uri)
+	cancel()
+
+	if p.probeDone != nil {
+		defer p.probeDone(e.uri, err)
+	}
+
+	p.mu.Lock()
+	e.probing = false
+	if err != nil {
+		e.downUntil = p.now().Add(p.cooldown)
+		p.mu.Unlock()
+		p.log(LogLevelWarn, "rongcloud endpoint probe failed",
+			LogField{"endpoint", e.uri}, LogField{"error", err})
+		return
+	}
+	e.failures = 0
+	e.downUntil = time.Time{}
+	p.mu.Unlock()
+	p.log(LogLevelInfo, "rongcloud endpoint recovered", LogField{"endpoint", e.uri})
+}
+
+func (p *endpointPool) log(level LogLevel, msg string, fields ...LogField) {
+	if p.logger.Enabled(context.Background(), level) {
+		p.logger.Log(context.Background(), level, msg, fields...)
+	}
+}
+
+// find 按地址查找，调用方需持有锁
+func (p *endpointPool) find(uri string) *endpoint {
+	for _, e := range p.endpoints {
+		if e.uri == uri {
+			return e
+		}
+	}
+	return nil
+}
+
+// fail 记录一次失败，连续失败达到上限时暂停使用该地址
+func (p *endpointPool) fail(uri string) {
+	p.mu.Lock()
+	e := p.find(uri)
+	if e == nil {
+		p.mu.Unlock()
+		return
+	}
+	now := p.now()
+	e.failures++
+	e.totalFailures++
+	e.lastFailure = now
+	if e.failures < p.maxFailures || !e.downUntil.IsZero() {
+		p.mu.Unlock()
+		return
+	}
+	e.downUntil = now.Add(p.cooldown)
+	failures, next := e.failures, p.active()
+	p.mu.Unlock()
+	p.metrics.Add(MetricFailovers, MetricLabels{Endpoint: uri}, 1)
+	p.log(LogLevelWarn, "rongcloud endpoint down",
+		LogField{"endpoint", uri}, LogField{"failures", failures},
+		LogField{"cooldownUntil", now.Add(p.cooldown)}, LogField{"failover", next.uri})
+}
+
+// succeed 记录一次成功，清零连续失败次数
+func (p *endpointPool) succeed(uri string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := p.find(uri)
+	if e == nil {
+		return
+	}
+	e.failures = 0
+	e.downUntil = time.Time{}
+}
+
+// skip 立即暂停使用当前地址
+func (p *endpointPool) skip() {
+	p.mu.Lock()
+	e := p.active()
+	if e == nil || !e.downUntil.IsZero() {
+		p.mu.Unlock()
+		return
+	}
+	now := p.now()
+	e.lastFailure = now
+	e.downUntil = now.Add(p.cooldown)
+	next := p.active()
+	p.mu.Unlock()
+	p.metrics.Add(MetricFailovers, MetricLabels{Endpoint: e.uri}, 1)
+	p.log(LogLevelInfo, "rongcloud endpoint skipped",
+		LogField{"endpoint", e.uri}, LogField{"failover", next.uri})
+}
+
+func (p *endpointPool) states() []EndpointState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	active := p.active()
+	states := make([]EndpointState, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		states = append(states, EndpointState{
+			URI:           e.uri,
+			Active:        e == active,
+			Healthy:       e.downUntil.IsZero(),
+			Failures:      e.failures,
+			TotalFailures: e.totalFailures,
+			LastFailure:   e.lastFailure,
+			CooldownUntil: e.downUntil,
+		})
+	}
+	return states
+}
+
+// probeEndpoint 默认探测方法，地址能正常响应（非 5xx）即视为可用
+func (rc *RongCloud) probeEndpoint(ctx context.Context, uri string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := rc.client().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("probe %s: status code %d", uri, resp.StatusCode)
+	}
+	return nil
+}
+
+// EndpointStates 获取所有 Api 地址的健康状态
+func (rc *RongCloud) EndpointStates() []EndpointState {
+	return rc.endpoints.states()
+}
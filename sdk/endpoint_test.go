@@ -0,0 +1,98 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEndpointPool_failover(t *testing.T) {
+	probed := make(chan string, 10)
+	results := make(chan error, 10)
+	done := make(chan error, 10)
+	probe := func(ctx context.Context, uri string) error {
+		probed <- uri
+		return <-results
+	}
+	p := newEndpointPool([]string{"http://a", "http://b", "http://c"}, 2, time.Minute, probe, time.Second)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	p.probeDone = func(uri string, err error) { done <- err }
+
+	if uri := p.pick(); uri != "http://a" {
+		t.Fatalf("expect http://a, got %s", uri)
+	}
+	p.fail("http://a")
+	if uri := p.pick(); uri != "http://a" {
+		t.Fatalf("expect http://a before reaching max failures, got %s", uri)
+	}
+	p.fail("http://a")
+	if uri := p.pick(); uri != "http://b" {
+		t.Fatalf("expect http://b, got %s", uri)
+	}
+
+	states := p.states()
+	if states[0].Healthy || states[0].TotalFailures != 2 || !states[1].Active {
+		t.Errorf("unexpected states: %+v", states)
+	}
+
+	// 冷却结束后探测失败，继续使用备用地址
+	now = now.Add(time.Minute)
+	results <- errors.New("unavailable")
+	if uri := p.pick(); uri != "http://b" {
+		t.Fatalf("expect http://b while probing, got %s", uri)
+	}
+	if uri := <-probed; uri != "http://a" {
+		t.Fatalf("expect probe http://a, got %s", uri)
+	}
+	if err := <-done; err == nil {
+		t.Fatal("expect probe error")
+	}
+	if states := p.states(); states[0].Healthy || !states[0].CooldownUntil.Equal(now.Add(time.Minute)) {
+		t.Errorf("expect another cooldown, got %+v", states[0])
+	}
+
+	// 探测成功后恢复使用
+	now = now.Add(time.Minute)
+	results <- nil
+	p.pick()
+	<-probed
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if uri := p.pick(); uri != "http://a" {
+		t.Fatalf("expect http://a after probe, got %s", uri)
+	}
+}
+
+func TestEndpointPool_allDown(t *testing.T) {
+	p := newEndpointPool([]string{"http://a", "http://b"}, 1, time.Minute, nil, time.Second)
+	p.fail("http://a")
+	p.fail("http://b")
+	if uri := p.pick(); uri != "http://a" {
+		t.Fatalf("expect the earliest recovered http://a, got %s", uri)
+	}
+	p.succeed("http://a")
+	if states := p.states(); !states[0].Healthy || states[1].Healthy {
+		t.Errorf("unexpected states: %+v", states)
+	}
+}
+
+func TestRongCloud_ChangeURI(t *testing.T) {
+	rc := NewRongCloud("key", "secret")
+	rc.ChangeURI()
+	if uri := rc.endpoints.pick(); uri != RONGCLOUDURI2 {
+		t.Errorf("expect %s, got %s", RONGCLOUDURI2, uri)
+	}
+
+	rc = NewRongCloud("key", "secret", WithRongCloudURIs("http://a", "http://b", "http://c", "http://d"))
+	rc.ChangeURI()
+	rc.ChangeURI()
+	if uri := rc.endpoints.pick(); uri != "http://c" {
+		t.Errorf("expect http://c, got %s", uri)
+	}
+	if states := rc.EndpointStates(); len(states) != 4 || !states[2].Active {
+		t.Errorf("unexpected states: %+v", states)
+	}
+}
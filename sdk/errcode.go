@@ -0,0 +1,39 @@
+package sdk
+
+//go:generate go run ./internal/errcodegen -in errcode.csv -out errcode_table.go
+
+// ErrorCategory 业务码分类
+type ErrorCategory string
+
+const (
+	CategorySuccess    ErrorCategory = "success"    // 成功
+	CategoryInternal   ErrorCategory = "internal"   // 服务端内部错误
+	CategoryAuth       ErrorCategory = "auth"       // App-Key、App Secret 或签名错误
+	CategoryParam      ErrorCategory = "param"      // 参数错误
+	CategoryPermission ErrorCategory = "permission" // 应用被锁定、服务未开通等
+	CategoryRateLimit  ErrorCategory = "rate_limit" // 调用频率超限
+	CategoryNotFound   ErrorCategory = "not_found"  // 用户、群组等不存在
+)
+
+// categorySentinels 业务码分类对应的错误类型
+var categorySentinels = map[ErrorCategory]error{
+	CategoryAuth:      ErrSignature,
+	CategoryParam:     ErrInvalidParam,
+	CategoryRateLimit: ErrRateLimited,
+	CategoryNotFound:  ErrNotFound,
+}
+
+// ErrorCodeInfo 业务码说明
+type ErrorCodeInfo struct {
+	Code        int
+	Category    ErrorCategory
+	Description string
+	Retryable   bool // 是否为临时错误，稍后重试可能成功；false 表示重试也不会成功
+}
+
+// LookupErrorCode 查询融云业务码说明，未收录的业务码返回 false
+// 码表由 errcode.csv 生成，新增业务码请修改 errcode.csv 后执行 go generate
+func LookupErrorCode(code int) (ErrorCodeInfo, bool) {
+	info, ok := errorCodes[code]
+	return info, ok
+}
@@ -0,0 +1,36 @@
+// Code generated by internal/errcodegen from errcode.csv; DO NOT EDIT.
+
+package sdk
+
+// errorCodes 融云业务码表
+var errorCodes = map[int]ErrorCodeInfo{
+	200:   {Code: 200, Category: CategorySuccess, Retryable: false, Description: "成功"},
+	1000:  {Code: 1000, Category: CategoryInternal, Retryable: true, Description: "服务内部错误"},
+	1001:  {Code: 1001, Category: CategoryAuth, Retryable: false, Description: "App Secret 错误"},
+	1002:  {Code: 1002, Category: CategoryParam, Retryable: false, Description: "参数错误"},
+	1003:  {Code: 1003, Category: CategoryParam, Retryable: false, Description: "请求 Content-Type 错误或请求体为空"},
+	1004:  {Code: 1004, Category: CategoryAuth, Retryable: false, Description: "签名错误"},
+	1005:  {Code: 1005, Category: CategoryParam, Retryable: false, Description: "参数长度超限"},
+	1006:  {Code: 1006, Category: CategoryPermission, Retryable: false, Description: "App 被锁定或删除"},
+	1007:  {Code: 1007, Category: CategoryPermission, Retryable: false, Description: "该方法被限制调用"},
+	1008:  {Code: 1008, Category: CategoryRateLimit, Retryable: true, Description: "调用频率超限"},
+	1009:  {Code: 1009, Category: CategoryPermission, Retryable: false, Description: "服务未开通"},
+	1010:  {Code: 1010, Category: CategoryNotFound, Retryable: false, Description: "用户不存在"},
+	1011:  {Code: 1011, Category: CategoryNotFound, Retryable: false, Description: "群组不存在"},
+	1012:  {Code: 1012, Category: CategoryNotFound, Retryable: false, Description: "聊天室不存在"},
+	1013:  {Code: 1013, Category: CategoryNotFound, Retryable: false, Description: "超级群不存在"},
+	1014:  {Code: 1014, Category: CategoryParam, Retryable: false, Description: "用户不在群组中"},
+	1015:  {Code: 1015, Category: CategoryNotFound, Retryable: false, Description: "要删除的保活聊天室 ID 不存在"},
+	1016:  {Code: 1016, Category: CategoryPermission, Retryable: false, Description: "设置保活聊天室个数超限"},
+	1017:  {Code: 1017, Category: CategoryPermission, Retryable: false, Description: "聊天室白名单用户个数超限"},
+	1018:  {Code: 1018, Category: CategoryPermission, Retryable: false, Description: "群组成员个数超限"},
+	1019:  {Code: 1019, Category: CategoryPermission, Retryable: false, Description: "用户加入的群组个数超限"},
+	1020:  {Code: 1020, Category: CategoryParam, Retryable: false, Description: "消息内容超过 128k"},
+	1021:  {Code: 1021, Category: CategoryPermission, Retryable: false, Description: "发送消息的用户被封禁"},
+	1022:  {Code: 1022, Category: CategoryPermission, Retryable: false, Description: "发送消息的用户被禁言"},
+	1023:  {Code: 1023, Category: CategoryParam, Retryable: false, Description: "频道 Id 不合法"},
+	1024:  {Code: 1024, Category: CategoryNotFound, Retryable: false, Description: "频道不存在"},
+	1050:  {Code: 1050, Category: CategoryInternal, Retryable: true, Description: "内部服务响应超时"},
+	1051:  {Code: 1051, Category: CategoryInternal, Retryable: true, Description: "服务繁忙，请稍后重试"},
+	10000: {Code: 10000, Category: CategorySuccess, Retryable: false, Description: "成功（v2 接口）"},
+}
@@ -0,0 +1,43 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLookupErrorCode(t *testing.T) {
+	info, ok := LookupErrorCode(1008)
+	if !ok || info.Category != CategoryRateLimit || !info.Retryable {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	info, ok = LookupErrorCode(1004)
+	if !ok || info.Category != CategoryAuth || info.Retryable {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	info, ok = LookupErrorCode(1011)
+	if !ok || info.Category != CategoryNotFound || info.Retryable {
+		t.Errorf("unexpected info: %+v", info)
+	}
+	if !errors.Is(RCErrorNew(1024, ""), ErrNotFound) {
+		t.Error("expect ErrNotFound")
+	}
+	if _, ok := LookupErrorCode(-1); ok {
+		t.Error("expect unknown code")
+	}
+	for code, info := range errorCodes {
+		if code != info.Code || info.Description == "" {
+			t.Errorf("invalid code info: %d %+v", code, info)
+		}
+	}
+}
+
+func TestError_Info(t *testing.T) {
+	var e *Error
+	if !errors.As(RCErrorNew(1002, "Paramer 'userId' is required"), &e) {
+		t.Fatal("expect *Error")
+	}
+	info, ok := e.Info()
+	if !ok || info.Category != CategoryParam {
+		t.Errorf("unexpected info: %+v", info)
+	}
+}
@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	"errors"
 	"strconv"
 	"sync"
 )
@@ -11,6 +12,136 @@ var codePool = sync.Pool{
 	},
 }
 
+// 可以通过 errors.Is 判断的错误类型
+//
+//	if errors.Is(err, sdk.ErrRateLimited) {
+//		// 稍后重试
+//	}
+var (
+	// ErrInvalidParam 参数错误，包括本地参数检查失败
+	ErrInvalidParam = errors.New("rongcloud: invalid param")
+	// ErrSignature App-Key、App Secret 或签名错误
+	ErrSignature = errors.New("rongcloud: signature verification failed")
+	// ErrRateLimited 调用频率超限
+	ErrRateLimited = errors.New("rongcloud: rate limited")
+	// ErrNotFound 用户、群组、聊天室等不存在，ErrUserNotFound、ErrGroupNotFound 之外的不存在错误也可通过它判断
+	ErrNotFound = errors.New("rongcloud: not found")
+	// ErrUserNotFound 用户不存在
+	// 已内置 errcode.csv 中用户不存在的业务码，其他接口返回的业务码可通过 RegisterErrorCode 关联
+	ErrUserNotFound = errors.New("rongcloud: user not found")
+	// ErrGroupNotFound 群组、超级群不存在
+	// 已内置 errcode.csv 中群组不存在的业务码，其他接口返回的业务码可通过 RegisterErrorCode 关联
+	ErrGroupNotFound = errors.New("rongcloud: group not found")
+)
+
+var (
+	codeSentinelsLock sync.RWMutex
+	// codeSentinels 通过 RegisterErrorCode 关联的错误类型，优先于内置的错误类型和业务码表中的分类
+	codeSentinels = map[int]error{}
+	// builtinCodeSentinels 内置的业务码对应的错误类型，与业务码表中的分类同时生效
+	builtinCodeSentinels = map[int]error{
+		1010: ErrUserNotFound,
+		1011: ErrGroupNotFound,
+		1013: ErrGroupNotFound,
+	}
+	// statusSentinels http 状态码对应的错误类型
+	statusSentinels = map[int]error{
+		401: ErrSignature,
+		429: ErrRateLimited,
+	}
+)
+
+// RegisterErrorCode 将业务码关联到错误类型，之后返回该业务码的错误可以通过 errors.Is(err, sentinel) 判断
+func RegisterErrorCode(code int, sentinel error) {
+	codeSentinelsLock.Lock()
+	defer codeSentinelsLock.Unlock()
+	codeSentinels[code] = sentinel
+}
+
+// unregisterErrorCode 删除 RegisterErrorCode 关联的错误类型
+func unregisterErrorCode(code int) {
+	codeSentinelsLock.Lock()
+	defer codeSentinelsLock.Unlock()
+	delete(codeSentinels, code)
+}
+
+// Error 融云 SDK 返回的错误
+// 业务错误、本地参数检查失败和网络错误都以 *Error 返回，网络错误等原始错误可通过 errors.Unwrap 获取
+type Error struct {
+	APIVersion int    // 接口版本，1 为 /xxx.json 接口，2 为 /v2 接口
+	HTTPStatus int    // http 状态码，未收到响应时为 0
+	Code       int    // 业务返回码，网络错误时为 0
+	Message    string // 错误信息
+	RequestID  string // 请求唯一标识，v2 接口为 RC-Request-Id
+	Endpoint   string // 请求的 Api 地址
+	Err        error  // 原始错误
+}
+
+// Error 获取错误信息
+func (e *Error) Error() string {
+	if e.Code == 0 && e.Err != nil {
+		return e.Err.Error()
+	}
+	return strconv.Itoa(e.Code) + ": " + e.Message
+}
+
+// ErrorCode 获取错误码
+func (e *Error) ErrorCode() int {
+	return e.Code
+}
+
+// Unwrap 获取原始错误
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is 支持 errors.Is 判断错误类型，target 为 *Error 时比较业务码
+func (e *Error) Is(target error) bool {
+	if t, ok := target.(*Error); ok {
+		return t.Code != 0 && t.Code == e.Code
+	}
+	if target == nil {
+		return false
+	}
+	if e.HTTPStatus != 0 && statusSentinels[e.HTTPStatus] == target {
+		return true
+	}
+	if e.Code == 0 {
+		return false
+	}
+	codeSentinelsLock.RLock()
+	sentinel, ok := codeSentinels[e.Code]
+	codeSentinelsLock.RUnlock()
+	if ok {
+		return sentinel == target
+	}
+	if builtinCodeSentinels[e.Code] == target {
+		return true
+	}
+	if info, ok := LookupErrorCode(e.Code); ok {
+		return categorySentinels[info.Category] == target
+	}
+	return false
+}
+
+// Info 获取业务码说明，未收录的业务码返回 false
+func (e *Error) Info() (ErrorCodeInfo, bool) {
+	return LookupErrorCode(e.Code)
+}
+
+// As 兼容 errors.As(err, &CodeResult{}) 等旧的错误类型
+func (e *Error) As(target interface{}) bool {
+	switch t := target.(type) {
+	case *CodeResult:
+		*t = CodeResult{e.Code, e.Message}
+		return e.Code != 0
+	case *CodeResultV2:
+		*t = CodeResultV2{e.Code, e.Message}
+		return e.Code != 0
+	}
+	return false
+}
+
 // CodeResult 融云返回状态码和错误码
 type CodeResult struct {
 	Code         int    `json:"code"`         // 返回码，200 为正常。
@@ -19,7 +150,7 @@ type CodeResult struct {
 
 // RCErrorNew 创建新的err信息
 func RCErrorNew(code int, text string) error {
-	return CodeResult{code, text}
+	return &Error{APIVersion: 1, Code: code, Message: text}
 }
 
 // Error 获取错误信息
@@ -46,7 +177,7 @@ type CodeResultV2 struct {
 
 // RCErrorNew 创建新的err信息
 func RCErrorNewV2(code int, text string) error {
-	return CodeResultV2{code, text}
+	return &Error{APIVersion: 2, Code: code, Message: text}
 }
 
 // Error 获取错误信息
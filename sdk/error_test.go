@@ -1,6 +1,11 @@
 package sdk
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -17,3 +22,78 @@ func TestCodeResult_Error(t *testing.T) {
 	err := CodeResult{200, "rcerr"}
 	t.Log(err.Error())
 }
+
+func TestError_Is(t *testing.T) {
+	cases := []struct {
+		err    error
+		target error
+		expect bool
+	}{
+		{RCErrorNew(1002, "Paramer 'userId' is required"), ErrInvalidParam, true},
+		{RCErrorNewV2(1002, "param 'groupId' is required"), ErrInvalidParam, true},
+		{RCErrorNew(1008, "too many requests"), ErrRateLimited, true},
+		{&Error{HTTPStatus: 429}, ErrRateLimited, true},
+		{&Error{HTTPStatus: 401, Code: 1004}, ErrSignature, true},
+		{RCErrorNew(1008, ""), ErrInvalidParam, false},
+		{RCErrorNew(1008, ""), &Error{Code: 1008}, true},
+		{fmt.Errorf("wrap: %w", RCErrorNew(1002, "")), ErrInvalidParam, true},
+		{RCErrorNew(1010, "user not found"), ErrUserNotFound, true},
+		{RCErrorNew(1010, "user not found"), ErrNotFound, true},
+		{RCErrorNew(1010, "user not found"), ErrGroupNotFound, false},
+		{RCErrorNew(1013, "ultragroup not found"), ErrGroupNotFound, true},
+	}
+	for i, c := range cases {
+		if got := errors.Is(c.err, c.target); got != c.expect {
+			t.Errorf("case %d: expect %v, got %v", i, c.expect, got)
+		}
+	}
+
+	RegisterErrorCode(-1, ErrUserNotFound)
+	t.Cleanup(func() { unregisterErrorCode(-1) })
+	if !errors.Is(RCErrorNew(-1, ""), ErrUserNotFound) {
+		t.Error("expect ErrUserNotFound")
+	}
+}
+
+func TestError_As(t *testing.T) {
+	var code CodeResult
+	if !errors.As(RCErrorNew(1002, "rcerr"), &code) || code.Code != 1002 || code.ErrorMessage != "rcerr" {
+		t.Errorf("unexpected CodeResult: %+v", code)
+	}
+	var codeV2 CodeResultV2
+	if !errors.As(RCErrorNewV2(1002, "rcerr"), &codeV2) || codeV2.Code != 1002 {
+		t.Errorf("unexpected CodeResultV2: %+v", codeV2)
+	}
+}
+
+func TestError_response(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"code":1008,"msg":"too many requests"}`))
+	}))
+	defer server.Close()
+
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL))
+	err, requestId := rc.UGGroupDismiss("g01")
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expect *Error, got %T", err)
+	}
+	if e.APIVersion != 2 || e.HTTPStatus != http.StatusTooManyRequests || e.Code != 1008 ||
+		e.Message != "too many requests" || e.Endpoint != server.URL || e.RequestID != requestId {
+		t.Errorf("unexpected error: %+v", e)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("expect ErrRateLimited")
+	}
+
+	server.Close()
+	_, err = rc.UserRegister("u01", "u01", "http://rongcloud.cn/portrait.jpg")
+	if !errors.As(err, &e) || e.Err == nil || e.Code != 0 || e.HTTPStatus != 0 {
+		t.Errorf("expect network error, got %+v", err)
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
+		t.Errorf("expect net.Error, got %T", errors.Unwrap(err))
+	}
+}
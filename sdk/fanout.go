@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 各接口单次调用的目标个数上限，开启 WithFanOut 后超出上限的目标按上限拆分为多批调用
+const (
+	PRIVATE_SEND_MAX_USERS   = 1000 // PrivateSend 每次最多 1000 个接收用户
+	PUSH_USER_MAX_USERS      = 100  // PushUser 每次最多 100 个用户
+	GROUP_SEND_MAX_GROUPS    = 3    // GroupSend 每次最多 3 个群组
+	GROUP_JOIN_MAX_MEMBERS   = 1000 // GroupJoin 每次最多 1000 个用户
+	GROUP_QUIT_MAX_MEMBERS   = 1000 // GroupQuit 每次最多 1000 个用户
+	BLACKLIST_ADD_MAX_USERS  = 20   // BlacklistAdd 每次最多 20 个用户
+	DEFAULT_FANOUT_PARALLELS = 4    // WithFanOut 默认的并发数
+)
+
+// WithFanOut 开启目标列表自动拆分，PrivateSend、PushUser、GroupSend、GroupJoin、GroupQuit、BlacklistAdd
+// 的目标超出单次上限时拆分为多批，最多 parallels 批同时调用，parallels 小于等于 0 时使用 DEFAULT_FANOUT_PARALLELS。
+// 部分批次失败时返回 *BatchError。每一批的结果可以通过 PushUserResObj、GroupJoinResObj、GroupQuitResObj、
+// BlacklistAddResObj 的返回值或 MessageRequest.Send 返回的 SendResult.Batches 获取，成功时同样返回
+func WithFanOut(parallels int) rongCloudOption {
+	return func(o *RongCloud) {
+		if parallels <= 0 {
+			parallels = DEFAULT_FANOUT_PARALLELS
+		}
+		o.fanOutParallels = parallels
+	}
+}
+
+// BatchResult 一批目标的调用结果
+type BatchResult struct {
+	Index   int      // 批次序号，从 0 开始
+	Targets []string // 本批的目标
+	Err     error    // 调用失败的错误，成功时为 nil
+}
+
+// BatchError 拆分调用时部分或全部批次失败，errors.Is、errors.As 按第一个失败批次的错误判断
+type BatchError struct {
+	Operation string        // 接口方法名
+	Batches   []BatchResult // 所有批次的结果，按批次序号排列
+}
+
+func (e *BatchError) Error() string {
+	failed := e.Failed()
+	return fmt.Sprintf("rongcloud: %s %d of %d batches failed: %v", e.Operation, len(failed), len(e.Batches), failed[0].Err)
+}
+
+// Unwrap 第一个失败批次的错误
+func (e *BatchError) Unwrap() error {
+	if failed := e.Failed(); len(failed) > 0 {
+		return failed[0].Err
+	}
+	return nil
+}
+
+// Failed 失败的批次
+func (e *BatchError) Failed() []BatchResult {
+	var failed []BatchResult
+	for _, b := range e.Batches {
+		if b.Err != nil {
+			failed = append(failed, b)
+		}
+	}
+	return failed
+}
+
+// FailedTargets 失败批次中的所有目标，可以用于重试
+func (e *BatchError) FailedTargets() []string {
+	var targets []string
+	for _, b := range e.Failed() {
+		targets = append(targets, b.Targets...)
+	}
+	return targets
+}
+
+// shouldFanOut 是否需要拆分 n 个目标
+func (rc *RongCloud) shouldFanOut(n, size int) bool {
+	return rc.fanOutParallels > 0 && n > size
+}
+
+// fanOut 按 size 拆分 targets，最多 fanOutParallels 批同时调用 fn，返回每一批的结果
+func (rc *RongCloud) fanOut(operation string, targets []string, size int, fn func(batch []string) error) ([]BatchResult, error) {
+	return rc.fanOutIndexed(operation, targets, size, func(_ int, batch []string) error {
+		return fn(batch)
+	})
+}
+
+// fanOutIndexed 同 fanOut，fn 的 index 为批次序号，用于按批次收集结果
+func (rc *RongCloud) fanOutIndexed(operation string, targets []string, size int, fn func(index int, batch []string) error) ([]BatchResult, error) {
+	var batches []BatchResult
+	for i := 0; i < len(targets); i += size {
+		end := i + size
+		if end > len(targets) {
+			end = len(targets)
+		}
+		batches = append(batches, BatchResult{Index: len(batches), Targets: targets[i:end:end]})
+	}
+
+	sem := make(chan struct{}, rc.fanOutParallels)
+	var wg sync.WaitGroup
+	for i := range batches {
+		b := &batches[i]
+		sem <- struct{}{}
+		if err := rc.Context().Err(); err != nil {
+			<-sem
+			b.Err = err
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			b.Err = fn(b.Index, b.Targets)
+		}()
+	}
+	wg.Wait()
+
+	for _, b := range batches {
+		if b.Err != nil {
+			return batches, &BatchError{Operation: operation, Batches: batches}
+		}
+	}
+	return batches, nil
+}
+
+// singleBatch 未拆分时的调用结果，只有一批
+func singleBatch(targets []string, err error) []BatchResult {
+	return []BatchResult{{Targets: targets, Err: err}}
+}
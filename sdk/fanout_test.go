@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+func fanOutUsers(n int) []string {
+	users := make([]string, n)
+	for i := range users {
+		users[i] = "u" + strconv.Itoa(i)
+	}
+	return users
+}
+
+func TestWithFanOut(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithFanOut(2))
+
+	msg := TXTMsg{Content: "hello"}
+	if err := rc.PrivateSend("u01", fanOutUsers(2500), "RC:TxtMsg", &msg, "", "", 0, 0, 1, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupSend("u01", fanOutUsers(7), nil, "RC:TxtMsg", &msg, "", "", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.PushUser(&PushNotification{PushContent: "hi", Android: map[string]interface{}{"Channel": "a"}}, fanOutUsers(250)...); err != nil {
+		t.Fatal(err)
+	}
+	batches := map[string][]int{}
+	for _, m := range srv.Messages() {
+		batches[m.Type] = append(batches[m.Type], len(m.To))
+	}
+	for typ, expect := range map[string]int{"private": 2500, "group": 7, "push": 250} {
+		total := 0
+		for _, n := range batches[typ] {
+			total += n
+		}
+		if total != expect {
+			t.Errorf("%s: expect %d targets, got %v", typ, expect, batches[typ])
+		}
+	}
+	if len(batches["private"]) != 3 || len(batches["group"]) != 3 || len(batches["push"]) != 3 {
+		t.Errorf("unexpected batches %v", batches)
+	}
+
+	if err := rc.GroupJoin("g01", "group", fanOutUsers(2500)...); err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := srv.Group("g01"); len(g.Members) != 2500 {
+		t.Errorf("expect 2500 members, got %d", len(g.Members))
+	}
+	if err := rc.GroupQuit(fanOutUsers(1500), "g01"); err != nil {
+		t.Fatal(err)
+	}
+	if g, _ := srv.Group("g01"); len(g.Members) != 1000 {
+		t.Errorf("expect 1000 members, got %d", len(g.Members))
+	}
+	if err := rc.BlacklistAdd("u01", fanOutUsers(45)); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Blacklist("u01")); n != 45 {
+		t.Errorf("expect 45 blacklisted users, got %d", n)
+	}
+}
+
+func TestWithFanOut_partial(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithFanOut(1))
+
+	srv.Inject(sdktest.Fault{Path: "/user/blacklist/add.json", Times: 1, Code: 1008})
+	users := fanOutUsers(45)
+	err := rc.BlacklistAdd("u01", users)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("expect *BatchError, got %v", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expect ErrRateLimited, got %v", err)
+	}
+	if len(batchErr.Batches) != 3 || len(batchErr.Failed()) != 1 || batchErr.Failed()[0].Index != 0 {
+		t.Errorf("unexpected batches %+v", batchErr.Batches)
+	}
+	if failed := batchErr.FailedTargets(); len(failed) != 20 || failed[0] != users[0] {
+		t.Errorf("unexpected failed targets %v", failed)
+	}
+	if n := len(srv.Blacklist("u01")); n != 25 {
+		t.Errorf("expect 25 blacklisted users, got %d", n)
+	}
+
+	// 未开启时保持原有行为
+	plain := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL))
+	if err := plain.GroupJoin("g01", "", fanOutUsers(1001)...); err == nil || errors.As(err, &batchErr) {
+		t.Errorf("expect parameter error, got %v", err)
+	}
+}
+
+func TestWithFanOut_report(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithFanOut(2))
+
+	batches, err := rc.GroupJoinResObj("g01", "group", fanOutUsers(2500)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 3 || len(batches[2].Targets) != 500 || batches[2].Index != 2 || batches[2].Err != nil {
+		t.Errorf("unexpected batches %+v", batches)
+	}
+	if batches, err = rc.BlacklistAddResObj("u01", fanOutUsers(5)); err != nil || len(batches) != 1 || len(batches[0].Targets) != 5 {
+		t.Errorf("expect a single batch, got %+v %v", batches, err)
+	}
+	if batches, err = rc.GroupQuitResObj(nil, "g01"); err == nil || batches != nil {
+		t.Errorf("expect parameter error without batches, got %+v %v", batches, err)
+	}
+
+	result, err := rc.NewMessage(MessageTargetPrivate).From("u01").To(fanOutUsers(2500)...).Content(&TXTMsg{Content: "hi"}).Send()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Batches) != 3 || len(result.Messages) != 2500 {
+		t.Errorf("unexpected result %d batches, %d messages", len(result.Batches), len(result.Messages))
+	}
+}
@@ -2,10 +2,8 @@ package sdk
 
 import (
 	"encoding/json"
+	"net/http"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // Group 群组信息
@@ -53,8 +51,7 @@ func (rc *RongCloud) GroupRemarksGetResObj(userId string, groupId string) (Group
 	if len(groupId) == 0 {
 		return result, RCErrorNew(1002, "Paramer 'groupId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/remarks/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupRemarksGetResObj", http.MethodPost, "/group/remarks/get.json")
 	rc.fillHeader(req)
 	req.Param("groupId", groupId)
 	req.Param("userId", userId)
@@ -82,8 +79,7 @@ func (rc *RongCloud) GroupRemarksGet(userId string, groupId string) ([]byte, err
 	if len(groupId) == 0 {
 		return nil, RCErrorNew(1002, "Paramer 'groupId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/remarks/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupRemarksGet", http.MethodPost, "/group/remarks/get.json")
 	rc.fillHeader(req)
 	req.Param("groupId", groupId)
 	req.Param("userId", userId)
@@ -108,8 +104,7 @@ func (rc *RongCloud) GroupRemarksDel(userId string, groupId string) error {
 	if len(groupId) == 0 {
 		return RCErrorNew(1002, "Paramer 'groupId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/remarks/del.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupRemarksDel", http.MethodPost, "/group/remarks/del.json")
 	rc.fillHeader(req)
 	req.Param("groupId", groupId)
 	req.Param("userId", userId)
@@ -137,8 +132,7 @@ func (rc *RongCloud) GroupRemarksSet(userId string, groupId string, remark strin
 	if len(remark) == 0 {
 		return RCErrorNew(1002, "Paramer 'remark' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/remarks/set.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupRemarksSet", http.MethodPost, "/group/remarks/set.json")
 	rc.fillHeader(req)
 	req.Param("groupId", groupId)
 	req.Param("userId", userId)
@@ -163,8 +157,7 @@ func (rc *RongCloud) GroupUserGagAdd(userId string, groupId string, minute strin
 	if len(minute) == 0 {
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/add.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupUserGagAdd", http.MethodPost, "/group/user/gag/add.json")
 	rc.fillHeader(req)
 	if len(groupId) > 0 {
 		req.Param("groupId", groupId)
@@ -208,8 +201,7 @@ func (rc *RongCloud) GroupUserQueryResObj(userId string) (GroupUserQueryObj, err
 	if len(userId) == 0 {
 		return result, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/group/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupUserQueryResObj", http.MethodPost, "/user/group/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 
@@ -232,8 +224,7 @@ func (rc *RongCloud) GroupUserQuery(userId string) ([]byte, error) {
 	if len(userId) == 0 {
 		return nil, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/group/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupUserQuery", http.MethodPost, "/user/group/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 
@@ -265,8 +256,7 @@ func (rc *RongCloud) GroupCreate(id, name string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/create." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupCreate", http.MethodPost, "/group/create."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -298,8 +288,7 @@ func (rc *RongCloud) GroupSync(id string, groups []Group) error {
 		return RCErrorNew(1002, "Paramer 'groups' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/sync." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupSync", http.MethodPost, "/group/sync."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("userId", id)
@@ -330,8 +319,7 @@ func (rc *RongCloud) GroupUpdate(id, name string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/refresh." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupUpdate", http.MethodPost, "/group/refresh."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", id)
@@ -353,17 +341,35 @@ func (rc *RongCloud) GroupUpdate(id, name string) error {
  *@return error
  */
 func (rc *RongCloud) GroupJoin(groupId, groupName string, memberId ...string) error {
+	_, err := rc.GroupJoinResObj(groupId, groupName, memberId...)
+	return err
+}
+
+// GroupJoinResObj : 同 GroupJoin，返回每一批的调用结果，未拆分时只有一批
+/*
+ *@return []BatchResult error
+ */
+func (rc *RongCloud) GroupJoinResObj(groupId, groupName string, memberId ...string) ([]BatchResult, error) {
 	if len(groupId) == 0 {
-		return RCErrorNew(1002, "Paramer 'id' is required")
+		return nil, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 	if len(memberId) == 0 {
-		return RCErrorNew(1002, "Paramer 'member' is required")
+		return nil, RCErrorNew(1002, "Paramer 'member' is required")
+	}
+	if rc.shouldFanOut(len(memberId), GROUP_JOIN_MAX_MEMBERS) {
+		return rc.fanOut("GroupJoin", memberId, GROUP_JOIN_MAX_MEMBERS, func(batch []string) error {
+			return rc.groupJoin(groupId, groupName, batch)
+		})
 	}
 	if len(memberId) > 1000 {
-		return RCErrorNew(1002, "Paramer 'member' More than 1000")
+		return nil, RCErrorNew(1002, "Paramer 'member' More than 1000")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/join." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	err := rc.groupJoin(groupId, groupName, memberId)
+	return singleBatch(memberId, err), err
+}
+
+func (rc *RongCloud) groupJoin(groupId, groupName string, memberId []string) error {
+	req := rc.newRequest("GroupJoin", http.MethodPost, "/group/join."+ReqType)
 	rc.fillHeader(req)
 	for k := range memberId {
 		req.Param("userId", memberId[k])
@@ -389,8 +395,7 @@ func (rc *RongCloud) GroupGet(id string) (Group, error) {
 	if id == "" {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/user/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupGet", http.MethodPost, "/group/user/query."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", id)
@@ -416,18 +421,35 @@ func (rc *RongCloud) GroupGet(id string) (Group, error) {
  *@return error
  */
 func (rc *RongCloud) GroupQuit(member []string, id string) error {
+	_, err := rc.GroupQuitResObj(member, id)
+	return err
+}
+
+// GroupQuitResObj : 同 GroupQuit，返回每一批的调用结果，未拆分时只有一批
+/*
+ *@return []BatchResult error
+ */
+func (rc *RongCloud) GroupQuitResObj(member []string, id string) ([]BatchResult, error) {
 	if len(member) == 0 {
-		return RCErrorNew(1002, "Paramer 'member' is required")
-	}
-	if len(member) > 1000 {
-		return RCErrorNew(1002, "Paramer 'member' More than 1000")
+		return nil, RCErrorNew(1002, "Paramer 'member' is required")
 	}
 	if id == "" {
-		return RCErrorNew(1002, "Paramer 'id' is required")
+		return nil, RCErrorNew(1002, "Paramer 'id' is required")
+	}
+	if rc.shouldFanOut(len(member), GROUP_QUIT_MAX_MEMBERS) {
+		return rc.fanOut("GroupQuit", member, GROUP_QUIT_MAX_MEMBERS, func(batch []string) error {
+			return rc.groupQuit(batch, id)
+		})
 	}
+	if len(member) > 1000 {
+		return nil, RCErrorNew(1002, "Paramer 'member' More than 1000")
+	}
+	err := rc.groupQuit(member, id)
+	return singleBatch(member, err), err
+}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/quit." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+func (rc *RongCloud) groupQuit(member []string, id string) error {
+	req := rc.newRequest("GroupQuit", http.MethodPost, "/group/quit."+ReqType)
 	rc.fillHeader(req)
 	for k := range member {
 		req.Param("userId", member[k])
@@ -456,8 +478,7 @@ func (rc *RongCloud) GroupDismiss(id, member string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/dismiss." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupDismiss", http.MethodPost, "/group/dismiss."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("userId", member)
@@ -491,8 +512,7 @@ func (rc *RongCloud) GroupGagAdd(id string, members []string, minute int) error
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupGagAdd", http.MethodPost, "/group/user/gag/add."+ReqType)
 	rc.fillHeader(req)
 	for _, item := range members {
 		req.Param("userId", item)
@@ -524,8 +544,7 @@ func (rc *RongCloud) GroupMuteMembersAdd(id string, members []string, minute int
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteMembersAdd", http.MethodPost, "/group/user/gag/add."+ReqType)
 	rc.fillHeader(req)
 	for _, item := range members {
 		req.Param("userId", item)
@@ -551,8 +570,7 @@ func (rc *RongCloud) GroupGagList(id string) (Group, error) {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupGagList", http.MethodPost, "/group/user/gag/list."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", id)
@@ -580,8 +598,7 @@ func (rc *RongCloud) GroupMuteMembersGetList(id string) (Group, error) {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteMembersGetList", http.MethodPost, "/group/user/gag/list."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", id)
@@ -614,8 +631,7 @@ func (rc *RongCloud) GroupGagRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupGagRemove", http.MethodPost, "/group/user/gag/rollback."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -646,8 +662,7 @@ func (rc *RongCloud) GroupMuteMembersRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteMembersRemove", http.MethodPost, "/group/user/gag/rollback."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -673,8 +688,7 @@ func (rc *RongCloud) GroupMuteAllMembersAdd(members []string) error {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteAllMembersAdd", http.MethodPost, "/group/ban/add."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -699,8 +713,7 @@ func (rc *RongCloud) GroupMuteAllMembersRemove(members []string) error {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteAllMembersRemove", http.MethodPost, "/group/ban/rollback."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -722,8 +735,7 @@ func (rc *RongCloud) GroupMuteAllMembersRemove(members []string) error {
  */
 func (rc *RongCloud) GroupMuteAllMembersGetList(members []string) (GroupInfo, error) {
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteAllMembersGetList", http.MethodPost, "/group/ban/query."+ReqType)
 	rc.fillHeader(req)
 	if len(members) > 0 {
 		for _, item := range members {
@@ -759,8 +771,7 @@ func (rc *RongCloud) GroupMuteWhiteListUserAdd(id string, members []string) erro
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteWhiteListUserAdd", http.MethodPost, "/group/user/ban/whitelist/add."+ReqType)
 	rc.fillHeader(req)
 	for _, item := range members {
 		req.Param("userId", item)
@@ -790,8 +801,7 @@ func (rc *RongCloud) GroupMuteWhiteListUserRemove(id string, members []string) e
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteWhiteListUserRemove", http.MethodPost, "/group/user/ban/whitelist/rollback."+ReqType)
 	rc.fillHeader(req)
 
 	for _, item := range members {
@@ -817,8 +827,7 @@ func (rc *RongCloud) GroupMuteWhiteListUserGetList(id string) ([]string, error)
 		return []string{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupMuteWhiteListUserGetList", http.MethodPost, "/group/user/ban/whitelist/query."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", id)
@@ -1,25 +1,121 @@
 package sdk
 
 import (
+	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"syscall"
-
-	"github.com/astaxie/beego/httplib"
+	"time"
 )
 
-func (rc *RongCloud) do(b *httplib.BeegoHTTPRequest) (body []byte, err error) {
-	return rc.httpRequest(b)
+// request 一次 API 调用的请求内容
+// 只记录方法、路径、参数等，真正的 http 请求在每次发送时按当前域名重新构建，便于重试和切换域名
+type request struct {
+	operation string // 接口方法名，如 PrivateSend
+	method    string
+	path      string
+	header    http.Header
+	params    url.Values
+	body      []byte
+	requestId string // v2 接口的 RC-Request-Id，重试时保持不变，服务端据此去重
+	version   int    // 接口版本，1 为 /xxx.json 接口，2 为 /v2 接口
+}
+
+// newRequest 创建请求，operation 为接口方法名，如 PrivateSend，path 为不含域名的接口路径
+func (rc *RongCloud) newRequest(operation, method, path string) *request {
+	return &request{
+		operation: operation,
+		method:    method,
+		path:      path,
+		header:    http.Header{},
+		params:    url.Values{},
+	}
+}
+
+// Header 设置请求头
+func (r *request) Header(key, value string) *request {
+	r.header.Set(key, value)
+	return r
+}
+
+// Param 添加表单参数，GET 请求时作为 query 参数
+func (r *request) Param(key, value string) *request {
+	r.params.Add(key, value)
+	return r
+}
+
+// Body 设置请求体，设置后 Param 不再作为表单发送
+func (r *request) Body(data []byte) *request {
+	r.body = data
+	return r
+}
+
+// JSONBody 将 obj 序列化为 json 请求体
+func (r *request) JSONBody(obj interface{}) (*request, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return r, err
+	}
+	r.Header("Content-Type", "application/json")
+	return r.Body(data), nil
+}
+
+// idempotent 请求是否可以安全地重复发送
+// v2 接口带有 RC-Request-Id，服务端会对重复请求去重
+func (r *request) idempotent() bool {
+	return r.requestId != "" || r.method == http.MethodGet || r.method == http.MethodHead
+}
+
+// build 按 uri 构建 http 请求
+// 与表单提交一致：GET、HEAD 请求的参数拼接到 url 上，其他请求未设置 body 时参数按表单编码作为 body
+func (r *request) build(ctx context.Context, uri string) (*http.Request, error) {
+	target := uri + r.path
+	var (
+		body io.Reader
+		form bool
+	)
+	switch {
+	case r.body != nil:
+		body = bytes.NewReader(r.body)
+	case len(r.params) == 0:
+	case r.method == http.MethodGet || r.method == http.MethodHead:
+		sep := "?"
+		if strings.Contains(target, "?") {
+			sep = "&"
+		}
+		target += sep + r.params.Encode()
+	default:
+		body = strings.NewReader(r.params.Encode())
+		form = true
+	}
+	hr, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	hr.Header = r.header.Clone()
+	if form && hr.Header.Get("Content-Type") == "" {
+		hr.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return hr, nil
+}
+
+func (rc *RongCloud) do(req *request) (body []byte, err error) {
+	return rc.httpRequest(req)
 }
 
 // 需要切换域名的网络错误
 func isNetError(err error) bool {
-	netErr, ok := err.(net.Error)
-	if !ok {
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
 		return false
 	}
 	// 超时
@@ -27,8 +123,7 @@ func isNetError(err error) bool {
 		return true
 	}
 
-	var opErr *net.OpError
-	opErr, ok = netErr.(*net.OpError)
+	opErr, ok := netErr.(*net.OpError)
 	if !ok {
 		//  url 错误
 		urlErr, ok := netErr.(*url.Error)
@@ -58,66 +153,233 @@ func isNetError(err error) bool {
 	return false
 }
 
-func (rc *RongCloud) httpRequest(b *httplib.BeegoHTTPRequest) (body []byte, err error) {
-	// 使用全局 httpClient，解决 http 打开端口过多问题
-	b.SetTransport(rc.globalTransport)
-	resp, err := b.DoRequest()
+// requestContext 生成单次请求使用的 ctx，调用方未设置 deadline 时使用 rc.timeout
+func (rc *RongCloud) requestContext() (context.Context, context.CancelFunc) {
+	ctx := rc.Context()
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, rc.timeout*time.Second)
+}
+
+// client 发送请求使用的 *http.Client
+// 未通过 WithHTTPClient 设置时使用 rc.globalTransport，解决 http 打开端口过多问题
+func (rc *RongCloud) client() *http.Client {
+	if rc.httpClient != nil {
+		return rc.httpClient
+	}
+	return &http.Client{Transport: rc.globalTransport}
+}
+
+// isDialError 建立连接失败，请求未发送到服务端
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (rc *RongCloud) httpRequest(req *request) (body []byte, err error) {
+	req.version = 1
+	return rc.send(req)
+}
+
+// v2 api
+func (rc *RongCloud) doV2(req *request) (body []byte, err error) {
+	req.version = 2
+	return rc.send(req)
+}
+
+// send 依次经过拦截器后发送请求
+func (rc *RongCloud) send(req *request) (body []byte, err error) {
+	inv, err := rc.invoke(req)
 	if err != nil {
-		if isNetError(err) {
-			rc.ChangeURI()
-		}
 		return nil, err
 	}
-	if resp.Body == nil {
-		return nil, nil
+	return inv.Response, nil
+}
+
+// invoke 依次经过拦截器后发送请求，返回调用信息，需要读取请求 ID 等响应信息时使用
+// 参数未通过 validateRequest 检查时不发送请求，也不经过拦截器
+func (rc *RongCloud) invoke(req *request) (*Invocation, error) {
+	inv := rc.newInvocation(req)
+	if err := validateRequest(req); err != nil {
+		inv.Err = err
+		return inv, err
 	}
-	defer resp.Body.Close()
-	rc.checkStatusCode(resp)
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		reader, err := gzip.NewReader(resp.Body)
+	err := rc.intercept(rc.invoker(req))(inv)
+	return inv, err
+}
+
+// invoker 实际发送请求的 Invoker，失败时按 rc.retryPolicy 重试
+func (rc *RongCloud) invoker(req *request) Invoker {
+	return func(inv *Invocation) error {
+		req.header, req.params, req.body = inv.Header, inv.Params, inv.Body
+		start := time.Now()
+		err := rc.waitRateLimit(req)
+		if err == nil {
+			err = rc.retry(req, inv)
+		}
+		inv.Latency = time.Since(start)
+		inv.Err = err
 		if err != nil {
-			return nil, err
+			rc.log(LogLevelWarn, "rongcloud request failed", func() []LogField {
+				return []LogField{
+					{"operation", req.operation}, {"path", req.path}, {"requestId", req.requestId},
+					{"attempts", inv.Attempts}, {"latency", inv.Latency},
+					{"status", inv.StatusCode}, {"code", inv.Code}, {"error", err},
+				}
+			})
 		}
-		body, err = ioutil.ReadAll(reader)
-	} else {
-		body, err = ioutil.ReadAll(resp.Body)
-	}
-	if err = checkHTTPResponseCode(body); err != nil {
-		return nil, err
+		return err
 	}
-	return body, err
 }
 
-// v2 api
-func (rc *RongCloud) doV2(b *httplib.BeegoHTTPRequest) (body []byte, err error) {
-	// 使用全局 httpClient，解决 http 打开端口过多问题
-	b.SetTransport(rc.globalTransport)
+// retry 发送请求，失败时按 rc.retryPolicy 重试
+func (rc *RongCloud) retry(req *request, inv *Invocation) error {
+	for attempt := 1; ; attempt++ {
+		inv.Attempts = attempt
+		start := time.Now()
+		apiErr := rc.attempt(req, inv)
+		latency := time.Since(start)
+		labels := MetricLabels{Operation: req.operation, Endpoint: inv.Endpoint, Status: inv.StatusCode, Code: inv.Code}
+		rc.metrics.Add(MetricRequests, labels, 1)
+		rc.metrics.Observe(MetricRequestDuration, labels, latency.Seconds())
+		if apiErr != nil {
+			rc.metrics.Add(MetricRequestErrors, labels, 1)
+		}
+		rc.log(LogLevelDebug, "rongcloud response", func() []LogField {
+			fields := []LogField{
+				{"operation", req.operation}, {"endpoint", inv.Endpoint}, {"attempt", attempt},
+				{"status", inv.StatusCode}, {"code", inv.Code}, {"latency", latency},
+			}
+			if apiErr != nil {
+				fields = append(fields, LogField{"error", apiErr})
+			}
+			return fields
+		})
+		if apiErr == nil {
+			return nil
+		}
+
+		info := RetryInfo{
+			Operation:  req.operation,
+			Method:     req.method,
+			Path:       req.path,
+			Attempt:    attempt,
+			StatusCode: apiErr.HTTPStatus,
+			Code:       apiErr.Code,
+			Err:        apiErr,
+			Idempotent: req.idempotent(),
+		}
+		if rc.Context().Err() != nil || !rc.retryPolicy.shouldRetry(info) {
+			return apiErr
+		}
+
+		delay := rc.retryPolicy.backoff(attempt)
+		rc.metrics.Add(MetricRetries, labels, 1)
+		rc.log(LogLevelInfo, "rongcloud retry", func() []LogField {
+			return []LogField{
+				{"operation", req.operation}, {"attempt", attempt}, {"delay", delay},
+				{"status", apiErr.HTTPStatus}, {"code", apiErr.Code}, {"error", apiErr},
+			}
+		})
+		timer := time.NewTimer(delay)
+		select {
+		case <-rc.Context().Done():
+			timer.Stop()
+			return apiErr
+		case <-timer.C:
+		}
+	}
+}
 
-	resp, err := b.DoRequest()
+// attempt 发送一次请求，响应信息记录到 inv
+func (rc *RongCloud) attempt(req *request, inv *Invocation) *Error {
+	ctx, cancel := rc.requestContext()
+	defer cancel()
+	uri := rc.endpoints.pick()
+	inv.Endpoint, inv.StatusCode, inv.Response, inv.Code = uri, 0, nil, 0
+	rc.log(LogLevelDebug, "rongcloud request", func() []LogField {
+		return []LogField{
+			{"operation", req.operation}, {"method", req.method}, {"endpoint", uri}, {"path", req.path},
+			{"requestId", req.requestId}, {"params", redactParams(req.params)}, {"bodySize", len(req.body)},
+		}
+	})
+	hr, err := req.build(ctx, uri)
+	if err != nil {
+		return req.error(uri, nil, err)
+	}
+	resp, err := rc.client().Do(hr)
 	if err != nil {
-		if isNetError(err) {
-			rc.ChangeURI()
+		// 调用方主动取消或超时不切换域名
+		if rc.Context().Err() == nil && isNetError(err) {
+			rc.endpoints.fail(uri)
 		}
-		return nil, err
+		return req.error(uri, nil, err)
+	}
+	inv.StatusCode = resp.StatusCode
+	if req.requestId == "" {
+		inv.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	// http status code 为 5xx 时记录地址失败，切换域名
+	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
+		rc.endpoints.fail(uri)
+	} else {
+		rc.endpoints.succeed(uri)
 	}
 	if resp.Body == nil {
-		return nil, nil
+		return nil
 	}
 	defer resp.Body.Close()
-	rc.checkStatusCode(resp)
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		reader, err := gzip.NewReader(resp.Body)
 		if err != nil {
-			return nil, err
+			return req.error(uri, resp, err)
 		}
-		body, err = ioutil.ReadAll(reader)
+		inv.Response, err = ioutil.ReadAll(reader)
 	} else {
-		body, err = ioutil.ReadAll(resp.Body)
+		inv.Response, err = ioutil.ReadAll(resp.Body)
 	}
-	if err = checkHTTPResponseCodeV2(body); err != nil {
-		return nil, err
+	if err != nil {
+		return req.error(uri, resp, err)
+	}
+	inv.Code = responseCode(inv.Response)
+	check := checkHTTPResponseCode
+	if req.version == 2 {
+		check = checkHTTPResponseCodeV2
+	}
+	if err = check(inv.Response); err != nil {
+		return req.error(uri, resp, err)
+	}
+	return nil
+}
+
+// error 生成带有请求信息的 *Error，resp 为 nil 表示未收到响应
+func (r *request) error(uri string, resp *http.Response, err error) *Error {
+	e, ok := err.(*Error)
+	if !ok {
+		e = &Error{Message: err.Error(), Err: err}
+	}
+	e.APIVersion = r.version
+	e.Endpoint = uri
+	e.RequestID = r.requestId
+	if resp != nil {
+		e.HTTPStatus = resp.StatusCode
+		if e.RequestID == "" {
+			e.RequestID = resp.Header.Get("X-Request-Id")
+		}
+	}
+	return e
+}
+
+// responseCode 解析响应中的业务返回码，无法解析时返回 0
+func responseCode(rep []byte) int {
+	var code struct {
+		Code int `json:"code"`
+	}
+	if err := json.Unmarshal(rep, &code); err != nil {
+		return 0
 	}
-	return body, err
+	return code.Code
 }
 
 func checkHTTPResponseCode(rep []byte) error {
@@ -127,7 +389,7 @@ func checkHTTPResponseCode(rep []byte) error {
 		return err
 	}
 	if code.Code != 200 {
-		return code
+		return &Error{Code: code.Code, Message: code.ErrorMessage}
 	}
 	return nil
 }
@@ -140,7 +402,7 @@ func checkHTTPResponseCodeV2(rep []byte) error {
 		return err
 	}
 	if code.Code != 10000 && code.Code != 200 {
-		return code
+		return &Error{Code: code.Code, Message: code.Message}
 	}
 	return nil
 }
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequest_build(t *testing.T) {
+	rc := NewRongCloud("key", "secret")
+
+	req := rc.newRequest("", http.MethodPost, "/user/getToken.json")
+	req.Param("userId", "u01")
+	req.Param("name", "a b")
+	hr, err := req.build(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(hr.Body)
+	if hr.URL.String() != "http://example.com/user/getToken.json" || string(body) != "name=a+b&userId=u01" {
+		t.Errorf("unexpected form request %s %s", hr.URL, body)
+	}
+	if hr.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected Content-Type %q", hr.Header.Get("Content-Type"))
+	}
+
+	req = rc.newRequest("", http.MethodGet, "/v2/ultragroups/g01?a=1")
+	req.Param("userId", "u01")
+	hr, err = req.build(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hr.URL.String() != "http://example.com/v2/ultragroups/g01?a=1&userId=u01" || hr.Body != nil {
+		t.Errorf("unexpected GET request %s", hr.URL)
+	}
+
+	req = rc.newRequest("", http.MethodPost, "/push/custom.json")
+	if _, err := req.JSONBody(map[string]string{"a": "b"}); err != nil {
+		t.Fatal(err)
+	}
+	req.Param("ignored", "1")
+	hr, err = req.build(context.Background(), "http://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = ioutil.ReadAll(hr.Body)
+	if string(body) != `{"a":"b"}` || hr.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected json request %s %q", body, hr.Header.Get("Content-Type"))
+	}
+	if hr.ContentLength != int64(len(body)) {
+		t.Errorf("expect Content-Length %d, got %d", len(body), hr.ContentLength)
+	}
+}
+
+type countingTransport struct {
+	count int
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.count++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Client")
+		_, _ = w.Write([]byte(`{"code":200,"userId":"u01","token":"t01"}`))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client := &http.Client{Transport: transport}
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithHTTPClient(client),
+		WithInterceptors(func(inv *Invocation, next Invoker) error {
+			inv.Header.Set("X-Client", "custom")
+			return next(inv)
+		}))
+	user, err := rc.UserRegister("u01", "name", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Token != "t01" || transport.count != 1 || header != "custom" {
+		t.Errorf("unexpected result %+v, %d requests", user, transport.count)
+	}
+
+	// SetHttpTransport 不影响自定义 client
+	rc.SetHttpTransport(http.DefaultTransport)
+	if _, err := rc.UserRegister("u01", "name", ""); err != nil {
+		t.Fatal(err)
+	}
+	if transport.count != 2 {
+		t.Errorf("expect 2 requests through custom client, got %d", transport.count)
+	}
+}
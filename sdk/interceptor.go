@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Invocation 一次 API 调用的信息，拦截器之间共享
+// 调用 next 之前可以修改 Params、Header、Body；调用 next 之后可以读取响应相关字段
+type Invocation struct {
+	Operation string      // 接口方法名，如 PrivateSend
+	Method    string      // http 方法
+	Path      string      // 接口路径，如 /message/private/publish.json
+	Header    http.Header // 请求头
+	Params    url.Values  // 表单参数
+	Body      []byte      // json 请求体，表单请求时为空
+	RequestID string      // 请求唯一标识，v2 接口为 RC-Request-Id，v1 接口为响应头 X-Request-Id
+
+	Endpoint   string        // 最后一次请求使用的 Api 地址
+	StatusCode int           // http 状态码
+	Response   []byte        // 原始响应内容
+	Code       int           // 业务返回码，无法解析时为 0
+	Attempts   int           // 请求次数，含重试
+	Latency    time.Duration // 耗时，含重试
+	Err        error         // 调用结果
+
+	ctx context.Context
+}
+
+// Context 调用方通过 WithContext 传入的 context
+func (inv *Invocation) Context() context.Context {
+	return inv.ctx
+}
+
+// Invoker 执行 API 调用，返回 nil 时 inv.Response 作为接口响应
+type Invoker func(inv *Invocation) error
+
+// Interceptor 请求拦截器
+// 调用 next 继续执行后续拦截器和请求；不调用 next 时直接结束调用，
+// 返回 nil 时使用 inv.Response 作为响应内容，可用于测试桩或缓存
+type Interceptor func(inv *Invocation, next Invoker) error
+
+// WithInterceptors 添加请求拦截器，按添加顺序执行，先添加的在外层
+func WithInterceptors(interceptors ...Interceptor) rongCloudOption {
+	return func(o *RongCloud) {
+		o.interceptors = append(o.interceptors[:len(o.interceptors):len(o.interceptors)], interceptors...)
+	}
+}
+
+// newInvocation 根据请求生成调用信息，参数与请求共享，拦截器的修改会作用于请求
+func (rc *RongCloud) newInvocation(req *request) *Invocation {
+	return &Invocation{
+		Operation: req.operation,
+		Method:    req.method,
+		Path:      req.path,
+		Header:    req.header,
+		Params:    req.params,
+		Body:      req.body,
+		RequestID: req.requestId,
+		ctx:       rc.Context(),
+	}
+}
+
+// intercept 将拦截器串联到 invoker 外层
+func (rc *RongCloud) intercept(invoker Invoker) Invoker {
+	for i := len(rc.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := rc.interceptors[i], invoker
+		invoker = func(inv *Invocation) error {
+			return interceptor(inv, next)
+		}
+	}
+	return invoker
+}
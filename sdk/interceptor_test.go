@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithInterceptors(t *testing.T) {
+	var tenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant = r.Header.Get("X-Tenant")
+		_, _ = w.Write([]byte(`{"code":200,"userId":"u01","token":"t01"}`))
+	}))
+	defer server.Close()
+
+	var (
+		order []string
+		got   Invocation
+	)
+	outer := func(inv *Invocation, next Invoker) error {
+		order = append(order, "outer")
+		inv.Header.Set("X-Tenant", "t1")
+		err := next(inv)
+		got = *inv
+		return err
+	}
+	inner := func(inv *Invocation, next Invoker) error {
+		order = append(order, "inner")
+		return next(inv)
+	}
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithInterceptors(outer, inner))
+	user, err := rc.UserRegister("u01", "name", "http://example.com/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Token != "t01" {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("unexpected order %v", order)
+	}
+	if tenant != "t1" {
+		t.Errorf("expect X-Tenant t1, got %q", tenant)
+	}
+	if got.Operation != "UserRegister" || got.Path != "/user/getToken.json" {
+		t.Errorf("unexpected operation %s %s", got.Operation, got.Path)
+	}
+	if got.Params.Get("userId") != "u01" {
+		t.Errorf("unexpected params %v", got.Params)
+	}
+	if got.Endpoint != server.URL || got.StatusCode != http.StatusOK || got.Code != 200 || got.Attempts != 1 {
+		t.Errorf("unexpected invocation %+v", got)
+	}
+	if got.Latency <= 0 || got.Err != nil || len(got.Response) == 0 {
+		t.Errorf("unexpected invocation %+v", got)
+	}
+}
+
+func TestWithInterceptors_shortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent")
+	}))
+	defer server.Close()
+
+	stub := func(inv *Invocation, next Invoker) error {
+		if inv.Operation == "UserRegister" {
+			inv.Response = []byte(`{"code":200,"userId":"u01","token":"stub"}`)
+			return nil
+		}
+		return errors.New("blocked")
+	}
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithInterceptors(stub))
+	user, err := rc.UserRegister("u01", "name", "http://example.com/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Token != "stub" {
+		t.Errorf("expect stub token, got %q", user.Token)
+	}
+	if err := rc.UserUpdate("u01", "name", ""); err == nil || err.Error() != "blocked" {
+		t.Errorf("expect blocked, got %v", err)
+	}
+}
+
+func TestWithInterceptors_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":1002,"errorMessage":"userId is required"}`))
+	}))
+	defer server.Close()
+
+	var got Invocation
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithInterceptors(func(inv *Invocation, next Invoker) error {
+		err := next(inv)
+		got = *inv
+		return err
+	}))
+	err := rc.UserUpdate("u01", "name", "")
+	if !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("expect ErrInvalidParam, got %v", err)
+	}
+	if got.Operation != "UserUpdate" || got.Code != 1002 || got.Err != err {
+		t.Errorf("unexpected invocation %+v", got)
+	}
+}
@@ -0,0 +1,98 @@
+// errcodegen 根据 errcode.csv 生成融云业务码表
+//
+//	go run ./internal/errcodegen -in errcode.csv -out errcode_table.go
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"go/format"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var categories = map[string]string{
+	"success":    "CategorySuccess",
+	"internal":   "CategoryInternal",
+	"auth":       "CategoryAuth",
+	"param":      "CategoryParam",
+	"permission": "CategoryPermission",
+	"rate_limit": "CategoryRateLimit",
+	"not_found":  "CategoryNotFound",
+}
+
+type row struct {
+	Code        int
+	Category    string
+	Retryable   bool
+	Description string
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by internal/errcodegen from errcode.csv; DO NOT EDIT.
+
+package sdk
+
+// errorCodes 融云业务码表
+var errorCodes = map[int]ErrorCodeInfo{
+{{- range .}}
+	{{.Code}}: {Code: {{.Code}}, Category: {{.Category}}, Retryable: {{.Retryable}}, Description: {{printf "%q" .Description}}},
+{{- end}}
+}
+`))
+
+func main() {
+	in := flag.String("in", "errcode.csv", "input csv file")
+	out := flag.String("out", "errcode_table.go", "output go file")
+	flag.Parse()
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var rows []row
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			log.Fatalf("line %d: invalid code: %v", i+1, err)
+		}
+		category, ok := categories[strings.TrimSpace(record[1])]
+		if !ok {
+			log.Fatalf("line %d: unknown category %q", i+1, record[1])
+		}
+		retryable, err := strconv.ParseBool(strings.TrimSpace(record[2]))
+		if err != nil {
+			log.Fatalf("line %d: invalid retryable: %v", i+1, err)
+		}
+		rows = append(rows, row{
+			Code:        code,
+			Category:    category,
+			Retryable:   retryable,
+			Description: strings.TrimSpace(record[3]),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, rows); err != nil {
+		log.Fatal(err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
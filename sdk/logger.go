@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// LogLevel 日志级别，取值与 log/slog 一致
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = -4
+	LogLevelInfo  LogLevel = 0
+	LogLevelWarn  LogLevel = 4
+	LogLevelError LogLevel = 8
+)
+
+// String 日志级别名称
+func (l LogLevel) String() string {
+	switch {
+	case l < LogLevelInfo:
+		return "DEBUG"
+	case l < LogLevelWarn:
+		return "INFO"
+	case l < LogLevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// LogField 结构化日志字段
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger 日志接口，SDK 通过它输出请求、响应、域名切换、重试等事件
+// 输出的参数已经过脱敏处理，不包含 App-Secret、签名和 token
+type Logger interface {
+	Enabled(ctx context.Context, level LogLevel) bool
+	Log(ctx context.Context, level LogLevel, msg string, fields ...LogField)
+}
+
+// nopLogger 默认的日志实现，不输出任何内容
+type nopLogger struct{}
+
+func (nopLogger) Enabled(context.Context, LogLevel) bool             { return false }
+func (nopLogger) Log(context.Context, LogLevel, string, ...LogField) {}
+
+// WithLogger 设置日志，默认不输出日志
+func WithLogger(logger Logger) rongCloudOption {
+	return func(o *RongCloud) {
+		if logger == nil {
+			logger = nopLogger{}
+		}
+		o.logger = logger
+	}
+}
+
+// log 输出日志，级别未开启时不生成字段
+func (rc *RongCloud) log(level LogLevel, msg string, fields func() []LogField) {
+	ctx := rc.Context()
+	if !rc.logger.Enabled(ctx, level) {
+		return
+	}
+	rc.logger.Log(ctx, level, msg, fields()...)
+}
+
+// redactedValue 脱敏后的取值
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys 需要脱敏的参数名和请求头，小写
+var sensitiveKeys = map[string]bool{
+	"app-secret":   true,
+	"appsecret":    true,
+	"signature":    true,
+	"rc-signature": true,
+	"token":        true,
+}
+
+// isSensitiveKey 判断参数名或请求头是否需要脱敏
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	return sensitiveKeys[key] || strings.HasSuffix(key, "token") || strings.HasSuffix(key, "secret")
+}
+
+// redactParams 复制参数并对敏感字段脱敏
+func redactParams(params url.Values) url.Values {
+	redacted := make(url.Values, len(params))
+	for k, v := range params {
+		if isSensitiveKey(k) {
+			redacted[k] = []string{redactedValue}
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
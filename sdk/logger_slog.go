@@ -0,0 +1,33 @@
+//go:build go1.21
+
+package sdk
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogLogger 基于 log/slog 的日志实现
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger 使用 *slog.Logger 输出日志，logger 为 nil 时使用 slog.Default()
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return slogLogger{logger: logger}
+}
+
+func (l slogLogger) Enabled(ctx context.Context, level LogLevel) bool {
+	return l.logger.Enabled(ctx, slog.Level(level))
+}
+
+func (l slogLogger) Log(ctx context.Context, level LogLevel, msg string, fields ...LogField) {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	l.logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+}
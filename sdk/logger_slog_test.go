@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	if logger.Enabled(context.Background(), LogLevelDebug) {
+		t.Error("debug should be disabled")
+	}
+	logger.Log(context.Background(), LogLevelWarn, "rongcloud endpoint down", LogField{"endpoint", "http://a"})
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "endpoint=http://a") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
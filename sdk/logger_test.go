@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type logEntry struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type recordLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordLogger) Enabled(context.Context, LogLevel) bool { return true }
+
+func (l *recordLogger) Log(_ context.Context, level LogLevel, msg string, fields ...LogField) {
+	entry := logEntry{level: level, msg: msg, fields: map[string]interface{}{}}
+	for _, f := range fields {
+		entry.fields[f.Key] = f.Value
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.mu.Unlock()
+}
+
+func (l *recordLogger) find(msg string) []logEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var entries []logEntry
+	for _, e := range l.entries {
+		if e.msg == msg {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func TestWithLogger(t *testing.T) {
+	var count int
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = w.Write([]byte(`{"code":10000}`))
+	}))
+	defer good.Close()
+
+	logger := &recordLogger{}
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	rc := NewRongCloud("key", "secret",
+		WithRongCloudURIs(bad.URL, good.URL), WithRetryPolicy(policy), WithLogger(logger))
+	if err, _ := rc.UGGroupCreate("u01", "g01", "group"); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expect 1 request to the backup endpoint, got %d", count)
+	}
+
+	requests := logger.find("rongcloud request")
+	if len(requests) != 2 || requests[0].fields["endpoint"] != bad.URL || requests[1].fields["endpoint"] != good.URL {
+		t.Errorf("unexpected request events %+v", requests)
+	}
+	if requests[0].fields["operation"] != "UGGroupCreate" || requests[0].level != LogLevelDebug {
+		t.Errorf("unexpected request event %+v", requests[0])
+	}
+	responses := logger.find("rongcloud response")
+	if len(responses) != 2 || responses[0].fields["status"] != http.StatusBadGateway || responses[1].fields["code"] != 10000 {
+		t.Errorf("unexpected response events %+v", responses)
+	}
+	down := logger.find("rongcloud endpoint down")
+	if len(down) != 1 || down[0].fields["endpoint"] != bad.URL || down[0].fields["failover"] != good.URL {
+		t.Errorf("unexpected failover events %+v", down)
+	}
+	if retries := logger.find("rongcloud retry"); len(retries) != 1 || retries[0].fields["attempt"] != 1 {
+		t.Errorf("unexpected retry events %+v", retries)
+	}
+	if failed := logger.find("rongcloud request failed"); len(failed) != 0 {
+		t.Errorf("unexpected failed events %+v", failed)
+	}
+	for _, e := range logger.entries {
+		if strings.Contains(fmt.Sprint(e.fields), "secret") {
+			t.Errorf("log leaks app secret: %+v", e)
+		}
+	}
+}
+
+func TestRedactParams(t *testing.T) {
+	params := url.Values{
+		"userId":    {"u01"},
+		"token":     {"t01"},
+		"Signature": {"s01"},
+		"appSecret": {"a01"},
+	}
+	redacted := redactParams(params)
+	if redacted.Get("userId") != "u01" {
+		t.Errorf("userId should not be redacted: %v", redacted)
+	}
+	for _, key := range []string{"token", "Signature", "appSecret"} {
+		if redacted.Get(key) != redactedValue {
+			t.Errorf("%s should be redacted: %v", key, redacted)
+		}
+	}
+	if params.Get("token") != "t01" {
+		t.Error("redactParams should not modify params")
+	}
+}
@@ -11,10 +11,8 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 const (
@@ -530,8 +528,7 @@ func (rc *RongCloud) MessageExpansionSet(msgUID, userId, conversationType, targe
 		return RCErrorNew(1002, "Paramer 'extraKeyVal' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/expansion/set.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("MessageExpansionSet", http.MethodPost, "/message/expansion/set.json")
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -577,8 +574,7 @@ func (rc *RongCloud) MessageExpansionDel(msgUID, userId, conversationType, targe
 		return RCErrorNew(1002, "Paramer 'extraKey' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/expansion/delete.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("MessageExpansionDel", http.MethodPost, "/message/expansion/delete.json")
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -622,8 +618,7 @@ func (rc *RongCloud) UGMessageModify(groupId, fromUserId, msgUID, content string
 		return nil, RCErrorNew(1002, "Paramer 'content' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/msg/modify.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageModify", http.MethodPost, "/ultragroup/msg/modify.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -690,8 +685,7 @@ func (rc *RongCloud) UGMessageGetObj(groupId string, msgList []UGMessageData, op
 	}
 	extOptions := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/msg/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageGetObj", http.MethodPost, "/ultragroup/msg/get.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -731,8 +725,7 @@ func (rc *RongCloud) UGMessageGet(groupId string, msgList []UGMessageData, optio
 	}
 	extOptions := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/msg/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageGet", http.MethodPost, "/ultragroup/msg/get.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -768,8 +761,7 @@ func (rc *RongCloud) UGMessageRecall(userId, targetId, messageId string, sentTim
 
 	extOptions := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageRecall", http.MethodPost, "/message/recall."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("fromUserId", userId)
@@ -810,8 +802,7 @@ func (rc *RongCloud) MessageBroadcastRecall(userId string, objectName string, co
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("MessageBroadcastRecall", http.MethodPost, "/message/broadcast."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", userId)
 	req.Param("objectName", objectName)
@@ -862,8 +853,7 @@ func (rc *RongCloud) ChatRoomRecall(userId string, targetId string, messageId st
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("ChatRoomRecall", http.MethodPost, "/message/recall."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("fromUserId", userId)
@@ -919,8 +909,7 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("SystemRecall", http.MethodPost, "/message/recall."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("fromUserId", userId)
@@ -944,10 +933,11 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 }
 
 // PrivateSend 发送单聊消息方法（一个用户向多个用户发送消息，单条消息最大 128k。每分钟最多发送 6000 条信息，每次发送用户上限为 1000 人，如：一次发送 1000 人时，示为 1000 条消息。）
+// 需要返回的消息 ID 时使用 PrivateSendResObj 或 rc.NewMessage(MessageTargetPrivate)
 /*
  *@param  senderID:发送人用户 ID。
  *@param  targetID:接收用户 ID。可以实现向多人发送消息，每次上限为 1000 人。
- *@param  objectName:发送的消息类型。
+ *@param  objectName:发送的消息类型，为空时按 msg 在 MessageRegistry 中注册的类型推断。
  *@param  msg:消息内容。
  *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息。如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
  *@param  pushData:针对 iOS 平台为 Push 通知时附加到 payload 中，Android 客户端收到推送消息时对应字段名为 pushData。
@@ -963,64 +953,34 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 func (rc *RongCloud) PrivateSend(senderID string, targetID []string, objectName string, msg rcMsg,
 	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
 	options ...MsgOption) error {
-	if senderID == "" {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	if len(targetID) == 0 {
-		return RCErrorNew(1002, "Paramer 'targetID' is required")
-	}
-
-	extraOptins := modifyMsgOptions(options)
-
-	req := httplib.Post(rc.rongCloudURI + "/message/private/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
-	req.Param("fromUserId", senderID)
-	for _, v := range targetID {
-		req.Param("toUserId", v)
-	}
-	req.Param("objectName", objectName)
-
-	msgr, err := msg.ToString()
-	if err != nil {
-		return err
-	}
-	req.Param("content", msgr)
-	req.Param("pushData", pushData)
-	req.Param("pushContent", pushContent)
-	req.Param("count", strconv.Itoa(count))
-	req.Param("verifyBlacklist", strconv.Itoa(verifyBlacklist))
-	req.Param("isPersisted", strconv.Itoa(isPersisted))
-	req.Param("contentAvailable", strconv.Itoa(contentAvailable))
-	req.Param("isIncludeSender", strconv.Itoa(isIncludeSender))
-	req.Param("expansion", strconv.FormatBool(extraOptins.expansion))
-	req.Param("disablePush", strconv.FormatBool(extraOptins.disablePush))
-	req.Param("isCounted", strconv.Itoa(extraOptins.isCounted))
-
-	if !extraOptins.disablePush && extraOptins.pushExt != "" {
-		req.Param("pushExt", extraOptins.pushExt)
-	}
-
-	if extraOptins.busChannel != "" {
-		req.Param("busChannel", extraOptins.busChannel)
-	}
-
-	if extraOptins.expansion && extraOptins.extraContent != "" {
-		req.Param("extraContent", extraOptins.extraContent)
-	}
-
-	_, err = rc.do(req)
-	if err != nil {
-		rc.urlError(err)
-	}
+	_, err := rc.PrivateSendResObj(senderID, targetID, objectName, msg, pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable, options...)
 	return err
 }
 
+// PrivateSendResObj 发送单聊消息，参数与 PrivateSend 相同，返回每个目标的消息 ID 和请求 ID
+func (rc *RongCloud) PrivateSendResObj(senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
+	options ...MsgOption) (*SendResult, error) {
+	return rc.NewMessage(MessageTargetPrivate).
+		From(senderID).
+		To(targetID...).
+		ObjectName(objectName).
+		Content(msg).
+		Options(options...).
+		PushContent(pushContent).
+		PushData(pushData).
+		Count(count).
+		VerifyBlacklist(verifyBlacklist != 0).
+		Persisted(isPersisted != 0).
+		IncludeSender(isIncludeSender != 0).
+		ContentAvailable(contentAvailable != 0).
+		Send()
+}
+
 // 私聊状态消息发送
 // senderID: 发送人用户 ID。
 // targetID: 接收用户 ID，支持向多人发送消息，每次上限为 1000 人。
-// objectName: 消息类型
+// objectName: 消息类型，为空时按 msg 的类型推断
 // msg: 所发送消息的内容
 // verifyBlacklist: 是否过滤发送人黑名单列表，0 表示为不过滤、 1 表示为过滤，默认为 0 不过滤。
 // isIncludeSender: 发送用户自己是否接收消息，0 表示为不接收，1 表示为接收，默认为 0 不接收。
@@ -1037,8 +997,12 @@ func (rc *RongCloud) PrivateStatusSend(senderID string, targetID []string, objec
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/statusmessage/private/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	objectName, err := defaultMessageRegistry.resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
+
+	req := rc.newRequest("PrivateStatusSend", http.MethodPost, "/statusmessage/private/publish."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
@@ -1090,8 +1054,7 @@ func (rc *RongCloud) PrivateRecall(senderID, targetID, uID string, sentTime int,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PrivateRecall", http.MethodPost, "/message/recall."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	req.Param("targetId", targetID)
@@ -1131,8 +1094,7 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/private/publish_template." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PrivateSendTemplate", http.MethodPost, "/message/private/publish_template."+ReqType)
 	rc.fillHeader(req)
 
 	var toUserIDs, push, pushData []string
@@ -1183,10 +1145,11 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
 }
 
 // GroupSend 发送群组消息方法（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息，每次最多向 3 个群组发送，如：一次向 3 个群组发送消息，示为 3 条消息。）
+// 需要返回的消息 ID 时使用 GroupSendResObj 或 rc.NewMessage(MessageTargetGroup)
 /*
  *@param  senderID:发送人用户 ID 。
  *@param  targetID:接收群ID.
- *@param  objectName:消息类型。
+ *@param  objectName:消息类型，为空时按 msg 在 MessageRegistry 中注册的类型推断。
  *@param  userID:群定向消群定向消息功能，向群中指定的一个或多个用户发送消息，群中其他用户无法收到该消息，当 targetID 为一个群组时此参数有效。注：如果开通了“单群聊消息云存储”功能，群定向消息不会存储到云端，向群中部分用户发送消息阅读状态回执时可使用此功能。（可选）
  *@param  msg:发送消息内容
  *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息. 如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
@@ -1199,68 +1162,31 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
  */
 func (rc *RongCloud) GroupSend(senderID string, targetID, userID []string, objectName string, msg rcMsg,
 	pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) error {
-	if senderID == "" {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	if len(targetID) == 0 {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	extraOptins := modifyMsgOptions(options)
-
-	req := httplib.Post(rc.rongCloudURI + "/message/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
-	req.Param("fromUserId", senderID)
-	for _, v := range targetID {
-		req.Param("toGroupId", v)
-	}
-	req.Param("objectName", objectName)
-	msgr, err := msg.ToString()
-	if err != nil {
-		rc.urlError(err)
-		return err
-	}
-	req.Param("content", msgr)
-	req.Param("pushContent", pushContent)
-	req.Param("pushData", pushData)
-	req.Param("isPersisted", strconv.Itoa(isPersisted))
-	req.Param("isIncludeSender", strconv.Itoa(isIncludeSender))
-	req.Param("isMentioned", strconv.Itoa(extraOptins.isMentioned))
-	req.Param("contentAvailable", strconv.Itoa(extraOptins.contentAvailable))
-	req.Param("expansion", strconv.FormatBool(extraOptins.expansion))
-	req.Param("disablePush", strconv.FormatBool(extraOptins.disablePush))
-
-	if !extraOptins.disablePush && extraOptins.pushExt != "" {
-		req.Param("pushExt", extraOptins.pushExt)
-	}
-
-	if len(userID) > 0 {
-		for _, v := range userID {
-			req.Param("toUserId", v)
-		}
-	}
-
-	if extraOptins.busChannel != "" {
-		req.Param("busChannel", extraOptins.busChannel)
-	}
-
-	if extraOptins.expansion && extraOptins.extraContent != "" {
-		req.Param("extraContent", extraOptins.extraContent)
-	}
-
-	_, err = rc.do(req)
-	if err != nil {
-		rc.urlError(err)
-	}
+	_, err := rc.GroupSendResObj(senderID, targetID, userID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, options...)
 	return err
 }
 
+// GroupSendResObj 发送群组消息，参数与 GroupSend 相同，返回每个目标的消息 ID 和请求 ID
+func (rc *RongCloud) GroupSendResObj(senderID string, targetID, userID []string, objectName string, msg rcMsg,
+	pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) (*SendResult, error) {
+	return rc.NewMessage(MessageTargetGroup).
+		From(senderID).
+		To(targetID...).
+		ToUsers(userID...).
+		ObjectName(objectName).
+		Content(msg).
+		Options(options...).
+		PushContent(pushContent).
+		PushData(pushData).
+		Persisted(isPersisted != 0).
+		IncludeSender(isIncludeSender != 0).
+		Send()
+}
+
 // 群聊状态消息发送
 // senderID: 发送人用户 ID。
 // toGroupIds: 接收群ID，提供多个本参数可以实现向多群发送消息，最多不超过 3 个群组。
-// objectName: 消息类型
+// objectName: 消息类型，为空时按 msg 的类型推断
 // msg: 所发送消息的内容
 // verifyBlacklist: 是否过滤发送人黑名单列表，0 表示为不过滤、 1 表示为过滤，默认为 0 不过滤。
 // isIncludeSender: 发送用户自己是否接收消息，0 表示为不接收，1 表示为接收，默认为 0 不接收。
@@ -1277,8 +1203,12 @@ func (rc *RongCloud) GroupStatusSend(senderID string, toGroupIds []string, objec
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/statusmessage/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	objectName, err := defaultMessageRegistry.resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
+
+	req := rc.newRequest("GroupStatusSend", http.MethodPost, "/statusmessage/group/publish."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	for _, v := range toGroupIds {
@@ -1327,8 +1257,7 @@ func (rc *RongCloud) GroupRecall(senderID, targetID, uID string, sentTime int,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupRecall", http.MethodPost, "/message/recall."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	req.Param("targetId", targetID)
@@ -1377,8 +1306,7 @@ func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, object
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("GroupSendMention", http.MethodPost, "/message/group/publish."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
@@ -1414,54 +1342,36 @@ func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, object
 }
 
 // ChatRoomSend 发送聊天室消息方法。（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息，每次最多向 3 个群组发送，如：一次向 3 个群组发送消息，示为 3 条消息。）
+// 需要返回的消息 ID 时使用 ChatRoomSendResObj 或 rc.NewMessage(MessageTargetChatRoom)
 /*
 *@param  senderID:发送人用户 ID 。
 *@param  targetID:接收聊天室ID, 建议最多不超过 10 个聊天室。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时按 msg 的类型推断
 *@param  msg:发送消息内容
 *
 *@return error
  */
 func (rc *RongCloud) ChatRoomSend(senderID string, targetID []string, objectName string, msg rcMsg, isPersisted, isIncludeSender int) error {
-	if senderID == "" {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	if len(targetID) == 0 {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	req := httplib.Post(rc.rongCloudURI + "/message/chatroom/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
-	req.Param("fromUserId", senderID)
-	for _, v := range targetID {
-		req.Param("toChatroomId", v)
-	}
-	req.Param("objectName", objectName)
-	req.Param("isPersisted", fmt.Sprint(isPersisted))
-
-	if isIncludeSender > 0 {
-		req.Param("isIncludeSender", fmt.Sprint(isIncludeSender))
-	}
-
-	msgr, err := msg.ToString()
-	if err != nil {
-		return err
-	}
-	req.Param("content", msgr)
-
-	_, err = rc.do(req)
-	if err != nil {
-		rc.urlError(err)
-	}
+	_, err := rc.ChatRoomSendResObj(senderID, targetID, objectName, msg, isPersisted, isIncludeSender)
 	return err
 }
 
+// ChatRoomSendResObj 发送聊天室消息，参数与 ChatRoomSend 相同，返回每个目标的消息 ID 和请求 ID
+func (rc *RongCloud) ChatRoomSendResObj(senderID string, targetID []string, objectName string, msg rcMsg, isPersisted, isIncludeSender int) (*SendResult, error) {
+	return rc.NewMessage(MessageTargetChatRoom).
+		From(senderID).
+		To(targetID...).
+		ObjectName(objectName).
+		Content(msg).
+		Persisted(isPersisted != 0).
+		IncludeSender(isIncludeSender > 0).
+		Send()
+}
+
 // ChatRoomBroadcast 向应用内所有聊天室广播消息方法，此功能需开通 专属服务（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息。）
 /*
 *@param  senderID:发送人用户 ID 。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时按 msg 的类型推断
 *@param  msg:发送消息内容
 * @param isIncludeSender:0或者1
 *@return error
@@ -1471,8 +1381,12 @@ func (rc *RongCloud) ChatRoomBroadcast(senderID, objectName string, msg rcMsg, i
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/chatroom/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	objectName, err := defaultMessageRegistry.resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
+
+	req := rc.newRequest("ChatRoomBroadcast", http.MethodPost, "/message/chatroom/broadcast."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	req.Param("objectName", objectName)
@@ -1509,8 +1423,7 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
 		return nil, RCErrorNew(1002, "Paramer 'content' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/online/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("OnlineBroadcast", http.MethodPost, "/message/online/broadcast."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", fromUserId)
 	req.Param("objectName", objectName)
@@ -1525,10 +1438,11 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
 }
 
 // SystemSend 一个用户向一个或多个用户发送系统消息，单条消息最大 128k，会话类型为 SYSTEM。
+// 需要返回的消息 ID 时使用 SystemSendResObj 或 rc.NewMessage(MessageTargetSystem)
 /*
 *@param  senderID:发送人用户 ID。
 *@param  targetID:接收用户 ID, 上限为 100 人。
-*@param  objectName:发送的消息类型。
+*@param  objectName:发送的消息类型，为空时按 msg 在 MessageRegistry 中注册的类型推断。
 *@param  msg:消息。
 *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息。如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
 *@param  pushData:针对 iOS 平台为 Push 通知时附加到 payload 中，Android 客户端收到推送消息时对应字段名为 pushData。
@@ -1541,55 +1455,30 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
  */
 func (rc *RongCloud) SystemSend(senderID string, targetID []string, objectName string, msg rcMsg,
 	pushContent, pushData string, count, isPersisted int, options ...MsgOption) error {
-
-	if senderID == "" {
-		return RCErrorNew(1002, "Paramer 'senderID' is required")
-	}
-
-	if len(targetID) == 0 {
-		return RCErrorNew(1002, "Paramer 'targetID' is required")
-	}
-
-	extraOptins := modifyMsgOptions(options)
-
-	req := httplib.Post(rc.rongCloudURI + "/message/system/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
-	req.Param("fromUserId", senderID)
-	for _, v := range targetID {
-		req.Param("toUserId", v)
-	}
-	req.Param("objectName", objectName)
-	msgr, err := msg.ToString()
-	if err != nil {
-		return err
-	}
-
-	req.Param("content", msgr)
-	req.Param("pushData", pushData)
-	req.Param("pushContent", pushContent)
-	req.Param("count", strconv.Itoa(count))
-	req.Param("isPersisted", strconv.Itoa(isPersisted))
-	req.Param("contentAvailable", strconv.Itoa(extraOptins.contentAvailable))
-	req.Param("disablePush", strconv.FormatBool(extraOptins.disablePush))
-	if !extraOptins.disablePush && extraOptins.pushExt != "" {
-		req.Param("pushExt", extraOptins.pushExt)
-	}
-	if extraOptins.busChannel != "" {
-		req.Param("busChannel", extraOptins.busChannel)
-	}
-
-	_, err = rc.do(req)
-	if err != nil {
-		rc.urlError(err)
-	}
+	_, err := rc.SystemSendResObj(senderID, targetID, objectName, msg, pushContent, pushData, count, isPersisted, options...)
 	return err
 }
 
+// SystemSendResObj 发送系统消息，参数与 SystemSend 相同，返回每个目标的消息 ID 和请求 ID
+func (rc *RongCloud) SystemSendResObj(senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, isPersisted int, options ...MsgOption) (*SendResult, error) {
+	return rc.NewMessage(MessageTargetSystem).
+		From(senderID).
+		To(targetID...).
+		ObjectName(objectName).
+		Content(msg).
+		Options(options...).
+		PushContent(pushContent).
+		PushData(pushData).
+		Count(count).
+		Persisted(isPersisted != 0).
+		Send()
+}
+
 // SystemBroadcast 给应用内所有用户发送消息方法，每小时最多发 2 次，每天最多发送 3 次（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息。）
 /*
 *@param  senderID:发送人用户 ID 。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时按 msg 的类型推断
 *@param  msg:发送消息内容
 *
 *@return error
@@ -1601,8 +1490,12 @@ func (rc *RongCloud) SystemBroadcast(senderID, objectName string, msg rcMsg, opt
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	objectName, err := defaultMessageRegistry.resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
+
+	req := rc.newRequest("SystemBroadcast", http.MethodPost, "/message/broadcast."+ReqType)
 	rc.fillHeader(req)
 	req.Param("fromUserId", senderID)
 	req.Param("objectName", objectName)
@@ -1647,8 +1540,7 @@ func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TX
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/system/publish_template." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("SystemSendTemplate", http.MethodPost, "/message/system/publish_template."+ReqType)
 	rc.fillHeader(req)
 
 	var toUserIDs, push, pushData []string
@@ -1700,8 +1592,7 @@ func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TX
 *@return History error
  */
 func (rc *RongCloud) HistoryGet(date string) (History, error) {
-	req := httplib.Post(rc.rongCloudURI + "/message/history." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("HistoryGet", http.MethodPost, "/message/history."+ReqType)
 	rc.fillHeader(req)
 	req.Param("date", date)
 
@@ -1727,8 +1618,7 @@ func (rc *RongCloud) HistoryRemove(date string) error {
 	if date == "" {
 		return RCErrorNew(1002, "Paramer 'date' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/message/history/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("HistoryRemove", http.MethodPost, "/message/history/delete."+ReqType)
 	rc.fillHeader(req)
 	req.Param("date", date)
 
@@ -1776,8 +1666,7 @@ func (rc *RongCloud) SetMessageExpansion(msgUID, userId, conversationType, targe
 		return err
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/expansion/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("SetMessageExpansion", http.MethodPost, "/message/expansion/set."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1829,8 +1718,7 @@ func (rc *RongCloud) DeleteMessageExpansion(msgUID, userId, conversationType, ta
 		return err
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/expansion/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("DeleteMessageExpansion", http.MethodPost, "/message/expansion/delete."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1864,8 +1752,7 @@ func (rc *RongCloud) QueryMessageExpansion(msgUID string, page int) ([]MessageEx
 		page = 1
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/expansion/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("QueryMessageExpansion", http.MethodPost, "/message/expansion/query."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1886,7 +1773,7 @@ func (rc *RongCloud) QueryMessageExpansion(msgUID string, page int) ([]MessageEx
 	}
 
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("response error: %d", resp.Code)
+		return nil, RCErrorNew(resp.Code, "Response error")
 	}
 
 	var data []MessageExpansionItem
@@ -0,0 +1,529 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 各会话类型单次发送的目标个数上限，单聊、群聊开启 WithFanOut 后超出上限时拆分为多批发送
+const (
+	SYSTEM_SEND_MAX_USERS = 100 // SystemSend 每次最多 100 个用户
+	UG_SEND_MAX_GROUPS    = 3   // UGMessagePublish 每次最多 3 个超级群
+)
+
+// MessageTarget 消息的发送目标
+type MessageTarget int
+
+const (
+	MessageTargetPrivate    MessageTarget = iota + 1 // MessageTargetPrivate 单聊，每次最多 1000 个用户
+	MessageTargetGroup                               // MessageTargetGroup 群聊，每次最多 3 个群组
+	MessageTargetSystem                              // MessageTargetSystem 系统消息，每次最多 100 个用户
+	MessageTargetChatRoom                            // MessageTargetChatRoom 聊天室
+	MessageTargetUltraGroup                          // MessageTargetUltraGroup 超级群，每次最多 3 个超级群
+)
+
+func (t MessageTarget) String() string {
+	switch t {
+	case MessageTargetPrivate:
+		return "private"
+	case MessageTargetGroup:
+		return "group"
+	case MessageTargetSystem:
+		return "system"
+	case MessageTargetChatRoom:
+		return "chatroom"
+	case MessageTargetUltraGroup:
+		return "ultragroup"
+	}
+	return "MessageTarget(" + strconv.Itoa(int(t)) + ")"
+}
+
+// operation 对应的接口方法名，用于拦截器、指标和 BatchError
+func (t MessageTarget) operation() string {
+	switch t {
+	case MessageTargetPrivate:
+		return "PrivateSend"
+	case MessageTargetGroup:
+		return "GroupSend"
+	case MessageTargetSystem:
+		return "SystemSend"
+	case MessageTargetChatRoom:
+		return "ChatRoomSend"
+	case MessageTargetUltraGroup:
+		return "UGMessagePublish"
+	}
+	return ""
+}
+
+// path 发送消息的接口路径
+func (t MessageTarget) path() string {
+	switch t {
+	case MessageTargetPrivate:
+		return "/message/private/publish." + ReqType
+	case MessageTargetGroup:
+		return "/message/group/publish." + ReqType
+	case MessageTargetSystem:
+		return "/message/system/publish." + ReqType
+	case MessageTargetChatRoom:
+		return "/message/chatroom/publish." + ReqType
+	case MessageTargetUltraGroup:
+		return "/message/ultragroup/publish." + ReqType
+	}
+	return ""
+}
+
+// maxTargets 单次发送的目标个数上限，0 为不限制
+func (t MessageTarget) maxTargets() int {
+	switch t {
+	case MessageTargetPrivate:
+		return PRIVATE_SEND_MAX_USERS
+	case MessageTargetGroup:
+		return GROUP_SEND_MAX_GROUPS
+	case MessageTargetSystem:
+		return SYSTEM_SEND_MAX_USERS
+	case MessageTargetUltraGroup:
+		return UG_SEND_MAX_GROUPS
+	}
+	return 0
+}
+
+// fanOut 是否支持 WithFanOut 拆分
+func (t MessageTarget) fanOut() bool {
+	return t == MessageTargetPrivate || t == MessageTargetGroup
+}
+
+// rawMsg 已经编码好的消息内容
+type rawMsg string
+
+func (m rawMsg) ToString() (string, error) {
+	return string(m), nil
+}
+
+// MessageRequest 发送消息的请求，通过 rc.NewMessage 创建，设置参数后调用 Send 发送
+//
+//	result, err := rc.NewMessage(sdk.MessageTargetPrivate).
+//		From("u01").
+//		To("u02", "u03").
+//		Content(&sdk.TXTMsg{Content: "hello"}).
+//		PushContent("你有一条新消息").
+//		Persisted(false).
+//		Send()
+//
+// 未设置的参数使用服务端默认值：存储消息、计入未读数、发送者不接收、不过滤黑名单
+type MessageRequest struct {
+	rc              *RongCloud
+	target          MessageTarget
+	from            string
+	to              []string
+	toUsers         []string
+	objectName      string
+	msg             rcMsg
+	count           int
+	persisted       bool
+	includeSender   bool
+	unreadCountFlag bool
+	opts            msgOptions
+	explicit        map[string]bool // 通过方法设置过的参数，超级群消息只发送设置过的 isPersisted、isMentioned、contentAvailable
+}
+
+// NewMessage 创建发送到 target 的消息
+func (rc *RongCloud) NewMessage(target MessageTarget) *MessageRequest {
+	return &MessageRequest{
+		rc:        rc,
+		target:    target,
+		persisted: true,
+		opts:      modifyMsgOptions(nil),
+	}
+}
+
+// From 发送人用户 ID
+func (m *MessageRequest) From(userID string) *MessageRequest {
+	m.from = userID
+	return m
+}
+
+// To 接收者，单聊、系统消息为用户 ID，群聊、聊天室、超级群为对应的会话 ID，可以多次调用追加
+func (m *MessageRequest) To(ids ...string) *MessageRequest {
+	m.to = append(m.to, ids...)
+	return m
+}
+
+// ToUsers 群定向消息，只发送给群中的指定用户，仅在向一个群组发送时有效
+func (m *MessageRequest) ToUsers(userIDs ...string) *MessageRequest {
+	m.toUsers = append(m.toUsers, userIDs...)
+	return m
+}
+
+// Content 消息内容，已在 MessageRegistry 中注册的消息无需设置 ObjectName
+func (m *MessageRequest) Content(msg rcMsg) *MessageRequest {
+	m.msg = msg
+	return m
+}
+
+// ObjectName 消息类型，未注册的自定义消息必须设置，已注册的消息必须与注册的类型一致
+func (m *MessageRequest) ObjectName(objectName string) *MessageRequest {
+	m.objectName = objectName
+	return m
+}
+
+// PushContent 定义显示的 Push 内容，自定义消息不设置时用户不会收到 Push 通知
+func (m *MessageRequest) PushContent(pushContent string) *MessageRequest {
+	m.opts.pushContent = pushContent
+	return m
+}
+
+// PushData iOS 平台 Push 通知附加到 payload 中的内容，Android 客户端对应字段名为 pushData
+func (m *MessageRequest) PushData(pushData string) *MessageRequest {
+	m.opts.pushData = pushData
+	return m
+}
+
+// PushExt 推送通知属性，JSON 字符串，DisablePush 为 true 时无效
+func (m *MessageRequest) PushExt(pushExt string) *MessageRequest {
+	m.opts.pushExt = pushExt
+	return m
+}
+
+// Count iOS 平台 Push 时显示的未读消息数，仅单聊、系统消息只有一个接收者时有效
+func (m *MessageRequest) Count(count int) *MessageRequest {
+	m.count = count
+	return m
+}
+
+// Persisted 老版本客户端收到不支持的消息时是否存储，默认为 true
+func (m *MessageRequest) Persisted(persisted bool) *MessageRequest {
+	m.persisted = persisted
+	m.mark("isPersisted")
+	return m
+}
+
+// Counted 用户未在线时是否计入未读消息数，默认为 true
+func (m *MessageRequest) Counted(counted bool) *MessageRequest {
+	m.opts.isCounted = boolInt(counted)
+	return m
+}
+
+// IncludeSender 发送者自己是否接收消息，默认为 false
+func (m *MessageRequest) IncludeSender(includeSender bool) *MessageRequest {
+	m.includeSender = includeSender
+	return m
+}
+
+// VerifyBlacklist 是否过滤发送人黑名单，仅单聊有效，默认为 false
+func (m *MessageRequest) VerifyBlacklist(verifyBlacklist bool) *MessageRequest {
+	m.opts.verifyBlacklist = boolInt(verifyBlacklist)
+	return m
+}
+
+// ContentAvailable iOS 平台是否为静默推送，默认为 false
+func (m *MessageRequest) ContentAvailable(contentAvailable bool) *MessageRequest {
+	m.opts.contentAvailable = boolInt(contentAvailable)
+	m.mark("contentAvailable")
+	return m
+}
+
+// Mentioned 是否为 @消息，仅群聊、超级群有效，默认为 false
+func (m *MessageRequest) Mentioned(mentioned bool) *MessageRequest {
+	m.opts.isMentioned = boolInt(mentioned)
+	m.mark("isMentioned")
+	return m
+}
+
+// mark 记录设置过的参数
+func (m *MessageRequest) mark(field string) {
+	if m.explicit == nil {
+		m.explicit = map[string]bool{}
+	}
+	m.explicit[field] = true
+}
+
+// DisablePush 是否为静默消息，为 true 时用户离线不会收到通知提醒，默认为 false
+func (m *MessageRequest) DisablePush(disablePush bool) *MessageRequest {
+	m.opts.disablePush = disablePush
+	return m
+}
+
+// Expansion 是否为可扩展消息，extraContent 为扩展信息的 JSON 键值对，可以为空
+func (m *MessageRequest) Expansion(expansion bool, extraContent string) *MessageRequest {
+	m.opts.expansion = expansion
+	m.opts.extraContent = extraContent
+	return m
+}
+
+// BusChannel 子会话 ID
+func (m *MessageRequest) BusChannel(busChannel string) *MessageRequest {
+	m.opts.busChannel = busChannel
+	return m
+}
+
+// UnreadCountFlag 超级群消息是否计入未读数，@消息时无效
+func (m *MessageRequest) UnreadCountFlag(unreadCountFlag bool) *MessageRequest {
+	m.unreadCountFlag = unreadCountFlag
+	return m
+}
+
+// Options 使用 MsgOption 设置参数，兼容原有的发送方法
+func (m *MessageRequest) Options(options ...MsgOption) *MessageRequest {
+	for _, option := range options {
+		option(&m.opts)
+	}
+	return m
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Validate 检查参数，不发送消息，返回全部未通过检查的参数
+func (m *MessageRequest) Validate() error {
+	_, _, err := m.encode()
+	return err
+}
+
+// encode 检查参数，返回 objectName 和编码后的消息内容，超级群接口为 v2 错误
+func (m *MessageRequest) encode() (objectName, content string, err error) {
+	v := newValidator(m.target.operation(), 1)
+	if m.target == MessageTargetUltraGroup {
+		v.apiVersion = 2
+	}
+	if m.target.operation() == "" {
+		v.add("target", "is invalid")
+	}
+	v.required("senderID", m.from)
+	v.userID("fromUserId", m.from)
+	if len(m.to) == 0 {
+		v.add("targetID", "is required")
+	}
+	if max := m.target.maxTargets(); max > 0 && len(m.to) > max &&
+		!(m.target.fanOut() && m.rc.shouldFanOut(len(m.to), max)) {
+		v.add("targetID", "must not exceed "+strconv.Itoa(max))
+	}
+	if m.target == MessageTargetPrivate || m.target == MessageTargetSystem {
+		for _, id := range m.to {
+			v.userID("toUserId", id)
+		}
+	}
+	if len(m.toUsers) > 0 && (m.target != MessageTargetGroup || len(m.to) != 1) {
+		v.add("userID", "is only valid when sending to one group")
+	}
+	for _, id := range m.toUsers {
+		v.userID("toUserId", id)
+	}
+	v.channelID("busChannel", m.opts.busChannel)
+
+	if objectName, err = defaultMessageRegistry.resolveObjectName(m.objectName, m.msg); err != nil {
+		v.addError("objectName", err)
+	} else if err = validateMsg(m.msg); err != nil {
+		v.addError("content", err)
+	} else if content, err = m.msg.ToString(); err != nil {
+		v.addError("content", err)
+	} else if content == "" {
+		v.add("content", "is required")
+	} else {
+		v.content("content", content)
+	}
+	if err = v.err(); err != nil {
+		return "", "", err
+	}
+	return objectName, content, nil
+}
+
+// validateMsg 检查实现了 Validate 方法的消息内容
+func validateMsg(msg rcMsg) error {
+	if v, ok := msg.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// Send 检查参数后发送消息，返回每个目标的消息 ID。开启 WithFanOut 时，单聊、群聊超出单次上限的目标拆分为多批发送，
+// 每一批的结果见 SendResult.Batches，部分批次失败时同时返回成功批次的结果和 *BatchError
+func (m *MessageRequest) Send() (*SendResult, error) {
+	objectName, content, err := m.encode()
+	if err != nil {
+		return nil, err
+	}
+	max := m.target.maxTargets()
+	if !m.target.fanOut() || !m.rc.shouldFanOut(len(m.to), max) {
+		sent, err := m.publish(m.to, objectName, content)
+		if err != nil {
+			return nil, err
+		}
+		return newSendResult(sent), nil
+	}
+
+	batches := make([][]SentMessage, (len(m.to)+max-1)/max)
+	results, err := m.rc.fanOutIndexed(m.target.operation(), m.to, max, func(index int, batch []string) error {
+		sent, err := m.publish(batch, objectName, content)
+		batches[index] = sent
+		return err
+	})
+	var sent []SentMessage
+	for _, b := range batches {
+		sent = append(sent, b...)
+	}
+	result := newSendResult(sent)
+	result.Batches = results
+	return result, err
+}
+
+// publish 向 to 发送一次消息
+func (m *MessageRequest) publish(to []string, objectName, content string) ([]SentMessage, error) {
+	var req *request
+	if m.target == MessageTargetUltraGroup {
+		var err error
+		if req, err = m.ultraGroupRequest(to, objectName, content); err != nil {
+			return nil, err
+		}
+		req.version = 2
+	} else {
+		req = m.formRequest(to, objectName, content)
+		req.version = 1
+	}
+
+	sentTime := time.Now().UnixNano() / int64(time.Millisecond)
+	inv, err := m.rc.invoke(req)
+	if err != nil {
+		m.rc.urlError(err)
+		return nil, err
+	}
+	return m.sentMessages(to, sentTime, inv), nil
+}
+
+// formRequest 单聊、群聊、系统消息、聊天室消息为表单请求
+func (m *MessageRequest) formRequest(to []string, objectName, content string) *request {
+	opts := m.opts
+	req := m.rc.newRequest(m.target.operation(), http.MethodPost, m.target.path())
+	m.rc.fillHeader(req)
+	req.Param("fromUserId", m.from)
+	switch m.target {
+	case MessageTargetPrivate:
+		for _, v := range to {
+			req.Param("toUserId", v)
+		}
+		req.Param("objectName", objectName)
+		req.Param("content", content)
+		req.Param("pushData", opts.pushData)
+		req.Param("pushContent", opts.pushContent)
+		req.Param("count", strconv.Itoa(m.count))
+		req.Param("verifyBlacklist", strconv.Itoa(opts.verifyBlacklist))
+		req.Param("isPersisted", strconv.Itoa(boolInt(m.persisted)))
+		req.Param("contentAvailable", strconv.Itoa(opts.contentAvailable))
+		req.Param("isIncludeSender", strconv.Itoa(boolInt(m.includeSender)))
+		req.Param("expansion", strconv.FormatBool(opts.expansion))
+		req.Param("disablePush", strconv.FormatBool(opts.disablePush))
+		req.Param("isCounted", strconv.Itoa(opts.isCounted))
+	case MessageTargetGroup:
+		for _, v := range to {
+			req.Param("toGroupId", v)
+		}
+		req.Param("objectName", objectName)
+		req.Param("content", content)
+		req.Param("pushContent", opts.pushContent)
+		req.Param("pushData", opts.pushData)
+		req.Param("isPersisted", strconv.Itoa(boolInt(m.persisted)))
+		req.Param("isIncludeSender", strconv.Itoa(boolInt(m.includeSender)))
+		req.Param("isMentioned", strconv.Itoa(opts.isMentioned))
+		req.Param("contentAvailable", strconv.Itoa(opts.contentAvailable))
+		req.Param("expansion", strconv.FormatBool(opts.expansion))
+		req.Param("disablePush", strconv.FormatBool(opts.disablePush))
+		for _, v := range m.toUsers {
+			req.Param("toUserId", v)
+		}
+	case MessageTargetSystem:
+		for _, v := range to {
+			req.Param("toUserId", v)
+		}
+		req.Param("objectName", objectName)
+		req.Param("content", content)
+		req.Param("pushData", opts.pushData)
+		req.Param("pushContent", opts.pushContent)
+		req.Param("count", strconv.Itoa(m.count))
+		req.Param("isPersisted", strconv.Itoa(boolInt(m.persisted)))
+		req.Param("contentAvailable", strconv.Itoa(opts.contentAvailable))
+		req.Param("disablePush", strconv.FormatBool(opts.disablePush))
+	case MessageTargetChatRoom:
+		for _, v := range to {
+			req.Param("toChatroomId", v)
+		}
+		req.Param("objectName", objectName)
+		req.Param("isPersisted", strconv.Itoa(boolInt(m.persisted)))
+		if m.includeSender {
+			req.Param("isIncludeSender", "1")
+		}
+		req.Param("content", content)
+	}
+
+	if m.target != MessageTargetChatRoom {
+		if !opts.disablePush && opts.pushExt != "" {
+			req.Param("pushExt", opts.pushExt)
+		}
+		if opts.busChannel != "" {
+			req.Param("busChannel", opts.busChannel)
+		}
+	}
+	if (m.target == MessageTargetPrivate || m.target == MessageTargetGroup) && opts.expansion && opts.extraContent != "" {
+		req.Param("extraContent", opts.extraContent)
+	}
+	return req
+}
+
+// ultraGroupRequest 超级群消息为 json 请求
+func (m *MessageRequest) ultraGroupRequest(to []string, objectName, content string) (*request, error) {
+	opts := m.opts
+	req := m.rc.newRequest(m.target.operation(), http.MethodPost, m.target.path())
+	m.rc.fillHeader(req)
+
+	body := map[string]interface{}{
+		"fromUserId": m.from,
+		"toGroupIds": to,
+		"objectName": objectName,
+		"content":    content,
+		"expansion":  opts.expansion,
+		"isCounted":  strconv.Itoa(opts.isCounted),
+	}
+	// 未设置时不发送，使用服务端默认值
+	if m.explicit["isPersisted"] || !m.persisted {
+		body["isPersisted"] = strconv.Itoa(boolInt(m.persisted))
+	}
+	if m.explicit["isMentioned"] || opts.isMentioned != 0 {
+		body["isMentioned"] = strconv.Itoa(opts.isMentioned)
+	}
+	if m.explicit["contentAvailable"] || opts.contentAvailable != 0 {
+		body["contentAvailable"] = strconv.Itoa(opts.contentAvailable)
+	}
+	if opts.pushContent != "" {
+		body["pushContent"] = opts.pushContent
+	}
+	if opts.pushData != "" {
+		body["pushData"] = opts.pushData
+	}
+	if opts.isMentioned != 1 {
+		body["unreadCountFlag"] = m.unreadCountFlag
+	}
+	if opts.busChannel != "" {
+		body["busChannel"] = opts.busChannel
+	}
+	if opts.extraContent != "" {
+		body["extraContent"] = opts.extraContent
+	}
+	if !opts.disablePush && opts.pushExt != "" {
+		body["pushExt"] = opts.pushExt
+	}
+
+	return req.JSONBody(body)
+}
+
+// encodePushExt 超级群的 pushExt 为 JSON 字符串
+func encodePushExt(pushExt *PushExt) (string, error) {
+	if pushExt == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(pushExt)
+	return string(b), err
+}
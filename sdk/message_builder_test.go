@@ -0,0 +1,179 @@
+package sdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// captureRequests 记录请求，不发送到服务端
+func captureRequests(invs *[]*Invocation) rongCloudOption {
+	return WithInterceptors(func(inv *Invocation, next Invoker) error {
+		*invs = append(*invs, inv)
+		inv.Response = []byte(`{"code":200}`)
+		return nil
+	})
+}
+
+func TestMessageRequest_private(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+
+	_, err := rc.NewMessage(MessageTargetPrivate).
+		From("u01").
+		To("u02", "u03").
+		Content(&TXTMsg{Content: "hello"}).
+		PushContent("new message").
+		Count(2).
+		Persisted(false).
+		Counted(false).
+		IncludeSender(true).
+		VerifyBlacklist(true).
+		BusChannel("ch01").
+		Send()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = rc.PrivateSend("u01", []string{"u02", "u03"}, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "new message", "",
+		2, 1, 0, 1, 0, WithMsgIsCounted(0), WithMsgBusChannel("ch01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 2 {
+		t.Fatalf("expect 2 requests, got %d", len(invs))
+	}
+	inv := invs[0]
+	if inv.Operation != "PrivateSend" || inv.Path != "/message/private/publish.json" {
+		t.Errorf("unexpected request %s %s", inv.Operation, inv.Path)
+	}
+	expect := map[string]string{
+		"fromUserId":      "u01",
+		"objectName":      "RC:TxtMsg",
+		"pushContent":     "new message",
+		"count":           "2",
+		"isPersisted":     "0",
+		"isCounted":       "0",
+		"isIncludeSender": "1",
+		"verifyBlacklist": "1",
+		"busChannel":      "ch01",
+	}
+	for k, v := range expect {
+		if got := inv.Params.Get(k); got != v {
+			t.Errorf("%s: expect %q, got %q", k, v, got)
+		}
+	}
+	if to := inv.Params["toUserId"]; len(to) != 2 {
+		t.Errorf("unexpected toUserId %v", to)
+	}
+	if !reflect.DeepEqual(inv.Params, invs[1].Params) {
+		t.Errorf("PrivateSend should send the same params as the builder\nbuilder %v\nlegacy  %v", inv.Params, invs[1].Params)
+	}
+}
+
+func TestMessageRequest_targets(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+	msg := &TXTMsg{Content: "hello"}
+
+	if _, err := rc.NewMessage(MessageTargetGroup).From("u01").To("g01").ToUsers("u02").Content(msg).Mentioned(true).Send(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rc.NewMessage(MessageTargetChatRoom).From("u01").To("c01").Content(msg).Send(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rc.NewMessage(MessageTargetSystem).From("u01").To("u02").Content(msg).Send(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rc.NewMessage(MessageTargetUltraGroup).From("u01").To("ug01").Content(msg).Mentioned(true).Expansion(true, `{"k":"v"}`).Send(); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 4 {
+		t.Fatalf("expect 4 requests, got %d", len(invs))
+	}
+
+	group := invs[0]
+	if group.Operation != "GroupSend" || group.Params.Get("toGroupId") != "g01" ||
+		group.Params.Get("toUserId") != "u02" || group.Params.Get("isMentioned") != "1" {
+		t.Errorf("unexpected group request %s %v", group.Operation, group.Params)
+	}
+	chatroom := invs[1]
+	if chatroom.Operation != "ChatRoomSend" || chatroom.Params.Get("toChatroomId") != "c01" || chatroom.Params.Has("isIncludeSender") {
+		t.Errorf("unexpected chatroom request %s %v", chatroom.Operation, chatroom.Params)
+	}
+	if system := invs[2]; system.Operation != "SystemSend" || system.Path != "/message/system/publish.json" {
+		t.Errorf("unexpected system request %s %s", system.Operation, system.Path)
+	}
+
+	ug := invs[3]
+	var body map[string]interface{}
+	if err := json.Unmarshal(ug.Body, &body); err != nil {
+		t.Fatal(err)
+	}
+	if ug.Operation != "UGMessagePublish" || body["objectName"] != "RC:TxtMsg" || body["isMentioned"] != "1" ||
+		body["expansion"] != true || body["extraContent"] != `{"k":"v"}` {
+		t.Errorf("unexpected ultragroup request %s %v", ug.Operation, body)
+	}
+	if _, ok := body["unreadCountFlag"]; ok {
+		t.Error("unreadCountFlag should not be sent with mentioned message")
+	}
+}
+
+func TestMessageRequest_validate(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+	msg := &TXTMsg{Content: "hello"}
+
+	cases := map[string]*MessageRequest{
+		"invalid target":     rc.NewMessage(MessageTarget(0)).From("u01").To("u02").Content(msg),
+		"without sender":     rc.NewMessage(MessageTargetPrivate).To("u02").Content(msg),
+		"without target":     rc.NewMessage(MessageTargetPrivate).From("u01").Content(msg),
+		"without content":    rc.NewMessage(MessageTargetPrivate).From("u01").To("u02"),
+		"too many users":     rc.NewMessage(MessageTargetSystem).From("u01").To(fanOutUsers(101)...).Content(msg),
+		"too many groups":    rc.NewMessage(MessageTargetUltraGroup).From("u01").To("ug1", "ug2", "ug3", "ug4").Content(msg),
+		"directed to groups": rc.NewMessage(MessageTargetGroup).From("u01").To("g01", "g02").ToUsers("u02").Content(msg),
+		"invalid message":    rc.NewMessage(MessageTargetPrivate).From("u01").To("u02").Content(&GIFMsg{}),
+		"empty raw content":  rc.NewMessage(MessageTargetPrivate).From("u01").To("u02").ObjectName("App:Raw").Content(rawMsg("")),
+	}
+	for name, m := range cases {
+		if _, err := m.Send(); err == nil {
+			t.Errorf("%s: expect error", name)
+		}
+	}
+	if len(invs) != 0 {
+		t.Errorf("invalid messages should not be sent, got %d requests", len(invs))
+	}
+
+	err := rc.UGMessagePublish("u01", "RC:TxtMsg", "", "", "", "1", "1", "0", "0", "", "", false, false, nil, "ug01")
+	if e, ok := err.(*Error); !ok || e.APIVersion != 2 {
+		t.Errorf("expect v2 parameter error, got %v", err)
+	}
+}
+
+func TestUGMessagePublish_optionalFields(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+
+	if err := rc.UGMessagePublish("u01", "RC:TxtMsg", `{"content":"hi"}`, "", "", "", "", "", "", "", "", false, true, nil, "ug01"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.UGMessagePublish("u01", "RC:TxtMsg", `{"content":"hi"}`, "", "", "0", "1", "0", "1", "", "", false, true, nil, "ug01"); err != nil {
+		t.Fatal(err)
+	}
+	bodies := make([]map[string]interface{}, len(invs))
+	for i, inv := range invs {
+		if err := json.Unmarshal(inv.Body, &bodies[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, field := range []string{"isPersisted", "isMentioned", "contentAvailable"} {
+		if _, ok := bodies[0][field]; ok {
+			t.Errorf("empty %s should not be sent, got %v", field, bodies[0])
+		}
+	}
+	if bodies[0]["isCounted"] != "1" || bodies[0]["unreadCountFlag"] != true {
+		t.Errorf("unexpected body %v", bodies[0])
+	}
+	if bodies[1]["isPersisted"] != "0" || bodies[1]["isMentioned"] != "0" || bodies[1]["contentAvailable"] != "1" {
+		t.Errorf("unexpected body %v", bodies[1])
+	}
+}
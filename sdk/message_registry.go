@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownObjectName objectName 没有注册
+var ErrUnknownObjectName = errors.New("rongcloud: unknown objectName")
+
+// defaultMessageRegistry 包级别的默认 MessageRegistry，发送消息时按它推断 objectName，
+// 消息路由、消息回调按它解析消息内容
+var defaultMessageRegistry = NewMessageRegistry()
+
+// MessageRegistry 管理 objectName 与消息类型的对应关系，用于编码、解析消息内容
+type MessageRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type   // objectName -> 消息结构体类型
+	names map[reflect.Type][]string // 消息结构体类型 -> objectName，按注册顺序排列
+}
+
+// NewMessageRegistry 创建 MessageRegistry，已注册融云内置消息
+func NewMessageRegistry() *MessageRegistry {
+	r := &MessageRegistry{
+		types: map[string]reflect.Type{},
+		names: map[reflect.Type][]string{},
+	}
+	builtins := []struct {
+		objectName string
+		msg        rcMsg
+	}{
+		{"RC:TxtMsg", &TXTMsg{}},
+		{"RC:ImgMsg", &ImgMsg{}},
+		{"RC:InfoNtf", &InfoNtf{}},
+		{"RC:VcMsg", &VCMsg{}},
+		{"RC:HQVCMsg", &HQVCMsg{}},
+		{"RC:ImgTextMsg", &IMGTextMsg{}},
+		{"RC:FileMsg", &FileMsg{}},
+		{"RC:LBSMsg", &LBSMsg{}},
+		{"RC:ProfileNtf", &ProfileNtf{}},
+		{"RC:CmdNtf", &CMDNtf{}},
+		{"RC:CmdMsg", &CMDMsg{}},
+		{"RC:ContactNtf", &ContactNtf{}},
+		{"RC:GrpNtf", &GrpNtf{}},
+		{"RC:DizNtf", &DizNtf{}},
+		{"RC:chrmKVNotiMsg", &ChatRoomKVNotiMessage{}},
+		{"RC:ReferenceMsg", &ReferenceMsg{}},
+		{"RC:GIFMsg", &GIFMsg{}},
+		{"RC:SightMsg", &SightMsg{}},
+		{"RC:CombineMsg", &CombineMsg{}},
+		{"RC:RcCmd", &RcCmdMsg{}},
+		{"RC:ReadNtf", &ReadNtf{}},
+		{"RC:TypSts", &TypSts{}},
+	}
+	for _, b := range builtins {
+		if err := r.Register(b.objectName, b.msg); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register 注册消息类型，msg 为消息结构体指针，如 &OrderCard{}
+// 同一类型可以注册多个 objectName，发送消息时推断为第一个注册的 objectName。objectName 已注册为其他类型时返回错误
+func (r *MessageRegistry) Register(objectName string, msg rcMsg) error {
+	if objectName == "" {
+		return RCErrorNew(1002, "Paramer 'objectName' is required")
+	}
+	t := reflect.TypeOf(msg)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return RCErrorNew(1002, "Paramer 'msg' must be a pointer")
+	}
+	t = t.Elem()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if old, ok := r.types[objectName]; ok {
+		if old == t {
+			return nil
+		}
+		return RCErrorNew(1002, "objectName '"+objectName+"' already registered as "+old.String())
+	}
+	r.types[objectName] = t
+	r.names[t] = append(r.names[t], objectName)
+	return nil
+}
+
+// Decode 按 objectName 解析消息内容，返回消息结构体指针
+func (r *MessageRegistry) Decode(objectName, content string) (rcMsg, error) {
+	r.mu.RLock()
+	t, ok := r.types[objectName]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownObjectName, objectName)
+	}
+	msg := reflect.New(t).Interface().(rcMsg)
+	if err := json.Unmarshal([]byte(content), msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Encode 编码消息内容，返回消息类型注册的 objectName
+func (r *MessageRegistry) Encode(msg rcMsg) (objectName, content string, err error) {
+	objectName, ok := r.ObjectName(msg)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %T", ErrUnknownObjectName, msg)
+	}
+	content, err = msg.ToString()
+	return objectName, content, err
+}
+
+// ObjectName 消息类型注册的第一个 objectName
+func (r *MessageRegistry) ObjectName(msg rcMsg) (string, bool) {
+	names := r.objectNames(msg)
+	if len(names) == 0 {
+		return "", false
+	}
+	return names[0], true
+}
+
+func (r *MessageRegistry) objectNames(msg rcMsg) []string {
+	t := reflect.TypeOf(msg)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.names[t.Elem()]
+}
+
+// ObjectNames 所有已注册的 objectName
+func (r *MessageRegistry) ObjectNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveObjectName 发送消息时确定 objectName：为空时按消息类型推断，
+// 不为空且消息类型已注册时必须是该类型注册的 objectName
+func (r *MessageRegistry) resolveObjectName(objectName string, msg rcMsg) (string, error) {
+	if msg == nil {
+		return "", RCErrorNew(1002, "Paramer 'msg' is required")
+	}
+	names := r.objectNames(msg)
+	if objectName == "" {
+		if len(names) == 0 {
+			return "", RCErrorNew(1002, "Paramer 'objectName' is required")
+		}
+		return names[0], nil
+	}
+	if len(names) == 0 {
+		return objectName, nil
+	}
+	for _, name := range names {
+		if name == objectName {
+			return objectName, nil
+		}
+	}
+	return "", RCErrorNew(1002, fmt.Sprintf("Paramer 'objectName' %s does not match message type %T", objectName, msg))
+}
+
+// RegisterMessage 在默认 MessageRegistry 中注册消息类型，注册后发送消息时 objectName 可以为空
+func RegisterMessage(objectName string, msg rcMsg) error {
+	return defaultMessageRegistry.Register(objectName, msg)
+}
+
+// DecodeMessage 使用默认 MessageRegistry 解析消息内容
+func DecodeMessage(objectName, content string) (rcMsg, error) {
+	return defaultMessageRegistry.Decode(objectName, content)
+}
+
+// EncodeMessage 使用默认 MessageRegistry 编码消息内容
+func EncodeMessage(msg rcMsg) (objectName, content string, err error) {
+	return defaultMessageRegistry.Encode(msg)
+}
+
+// MessageObjectName 消息类型在默认 MessageRegistry 中注册的 objectName
+func MessageObjectName(msg rcMsg) (string, bool) {
+	return defaultMessageRegistry.ObjectName(msg)
+}
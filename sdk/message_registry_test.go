@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+// orderCard 测试用的自定义消息
+type orderCard struct {
+	OrderID string `json:"orderId"`
+	Amount  int    `json:"amount"`
+}
+
+func (m *orderCard) ToString() (string, error) {
+	b, err := json.Marshal(m)
+	return string(b), err
+}
+
+func TestMessageRegistry(t *testing.T) {
+	r := NewMessageRegistry()
+	if err := r.Register("App:OrderCard", &orderCard{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register("App:OrderCard", &orderCard{}); err != nil {
+		t.Errorf("re-register the same type: %v", err)
+	}
+	if err := r.Register("App:OrderCard", &TXTMsg{}); err == nil {
+		t.Error("expect error when objectName is registered as another type")
+	}
+	if err := r.Register("App:Card", orderCardValue{}); err == nil {
+		t.Error("expect error for non-pointer message")
+	}
+
+	name, content, err := r.Encode(&orderCard{OrderID: "o1", Amount: 3})
+	if err != nil || name != "App:OrderCard" || content != `{"orderId":"o1","amount":3}` {
+		t.Fatalf("unexpected encode result %s %s %v", name, content, err)
+	}
+	msg, err := r.Decode(name, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card, ok := msg.(*orderCard); !ok || card.OrderID != "o1" || card.Amount != 3 {
+		t.Errorf("unexpected decoded message %#v", msg)
+	}
+
+	if msg, err := r.Decode("RC:TxtMsg", `{"content":"hi"}`); err != nil || msg.(*TXTMsg).Content != "hi" {
+		t.Errorf("unexpected builtin message %#v %v", msg, err)
+	}
+	if _, err := r.Decode("App:Unknown", "{}"); !errors.Is(err, ErrUnknownObjectName) {
+		t.Errorf("expect ErrUnknownObjectName, got %v", err)
+	}
+	if _, _, err := r.Encode(&unregisteredMsg{}); !errors.Is(err, ErrUnknownObjectName) {
+		t.Errorf("expect ErrUnknownObjectName, got %v", err)
+	}
+}
+
+type orderCardValue struct{}
+
+func (orderCardValue) ToString() (string, error) { return "{}", nil }
+
+type unregisteredMsg struct{}
+
+func (*unregisteredMsg) ToString() (string, error) { return "{}", nil }
+
+func TestRongCloud_PrivateSend_inferObjectName(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL))
+
+	if err := RegisterMessage("App:OrderCard", &orderCard{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.PrivateSend("u01", []string{"u02"}, "", &orderCard{OrderID: "o1"}, "", "", 0, 0, 1, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupSend("u01", []string{"g01"}, nil, "", &TXTMsg{Content: "hi"}, "", "", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	msgs := srv.Messages()
+	if len(msgs) != 2 || msgs[0].ObjectName != "App:OrderCard" || msgs[1].ObjectName != "RC:TxtMsg" {
+		t.Errorf("unexpected messages %+v", msgs)
+	}
+
+	// objectName 与消息类型不一致
+	if err := rc.PrivateSend("u01", []string{"u02"}, "RC:ImgMsg", &TXTMsg{Content: "hi"}, "", "", 0, 0, 1, 0, 0); err == nil {
+		t.Error("expect mismatch error")
+	}
+	// 未注册的消息必须指定 objectName
+	if err := rc.SystemSend("u01", []string{"u02"}, "", &unregisteredMsg{}, "", "", 0, 1); err == nil {
+		t.Error("expect objectName required error")
+	}
+	if err := rc.SystemSend("u01", []string{"u02"}, "App:Unregistered", &unregisteredMsg{}, "", "", 0, 1); err != nil {
+		t.Errorf("unregistered message with objectName: %v", err)
+	}
+	if msgs := srv.Messages(); len(msgs) != 3 || msgs[2].Type != "system" {
+		t.Errorf("unexpected messages %+v", msgs)
+	}
+
+	route := &RoutedMessage{}
+	route.Message = decodeMessageContent("App:OrderCard", `{"orderId":"o2"}`)
+	if card, ok := route.Message.(*orderCard); !ok || card.OrderID != "o2" {
+		t.Errorf("unexpected routed message %#v", route.Message)
+	}
+}
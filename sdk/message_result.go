@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// SentMessage 发送给一个目标的消息
+type SentMessage struct {
+	Target     MessageTarget
+	From       string // 发送人用户 ID
+	TargetID   string // 接收用户、群组、聊天室或超级群 ID
+	MessageUID string // 服务端分配的消息 ID，服务端未返回时为空
+	// SentTime 发送时间，毫秒。服务端返回发送时间时为服务端时间，否则为发送请求前的本地时间，
+	// 与服务端记录的发送时间可能不同，撤回消息等需要准确时间时请使用消息路由中的 MsgTimestamp
+	SentTime   int64
+	BusChannel string
+	RequestID  string // 发送该消息的请求 ID
+}
+
+// SendResult 发送消息的结果
+type SendResult struct {
+	RequestID string        // 请求 ID，拆分为多批发送时为第一个成功批次的请求 ID，各目标的请求 ID 见 Messages
+	Messages  []SentMessage // 每个目标的消息，按 To 的顺序排列，拆分发送时不含失败批次的目标
+	Batches   []BatchResult // 拆分发送时每一批的结果，未拆分时为 nil
+}
+
+func newSendResult(sent []SentMessage) *SendResult {
+	r := &SendResult{Messages: sent}
+	if len(sent) > 0 {
+		r.RequestID = sent[0].RequestID
+	}
+	return r
+}
+
+// Message 发送给 targetID 的消息
+func (r *SendResult) Message(targetID string) (SentMessage, bool) {
+	for _, m := range r.Messages {
+		if m.TargetID == targetID {
+			return m, true
+		}
+	}
+	return SentMessage{}, false
+}
+
+// MessageUIDs 目标 ID 到消息 ID 的映射，不含服务端未返回消息 ID 的目标
+func (r *SendResult) MessageUIDs() map[string]string {
+	uids := make(map[string]string, len(r.Messages))
+	for _, m := range r.Messages {
+		if m.MessageUID != "" {
+			uids[m.TargetID] = m.MessageUID
+		}
+	}
+	return uids
+}
+
+// sendResponse 发送消息接口的响应，messageUIDs 按目标类型返回 userId、groupId 或 chatroomId
+// sentTime 为服务端的发送时间，未返回时为 0
+type sendResponse struct {
+	MessageUID  string `json:"messageUID"`
+	SentTime    int64  `json:"sentTime"`
+	MessageUIDs []struct {
+		UserID     string `json:"userId"`
+		GroupID    string `json:"groupId"`
+		ChatroomID string `json:"chatroomId"`
+		MessageUID string `json:"messageUID"`
+		SentTime   int64  `json:"sentTime"`
+	} `json:"messageUIDs"`
+}
+
+// sentMessages 按响应生成每个目标的发送结果，localTime 为发送请求前的本地时间，服务端未返回发送时间时使用
+func (m *MessageRequest) sentMessages(to []string, localTime int64, inv *Invocation) []SentMessage {
+	var resp sendResponse
+	_ = json.Unmarshal(inv.Response, &resp)
+	if resp.SentTime > 0 {
+		localTime = resp.SentTime
+	}
+	uids := map[string]string{}
+	sentTimes := map[string]int64{}
+	for _, v := range resp.MessageUIDs {
+		for _, id := range []string{v.UserID, v.GroupID, v.ChatroomID} {
+			if id != "" {
+				uids[id] = v.MessageUID
+				sentTimes[id] = v.SentTime
+			}
+		}
+	}
+	if resp.MessageUID != "" && len(to) == 1 {
+		uids[to[0]] = resp.MessageUID
+	}
+
+	sent := make([]SentMessage, len(to))
+	for i, id := range to {
+		sentTime := localTime
+		if sentTimes[id] > 0 {
+			sentTime = sentTimes[id]
+		}
+		sent[i] = SentMessage{
+			Target:     m.target,
+			From:       m.from,
+			TargetID:   id,
+			MessageUID: uids[id],
+			SentTime:   sentTime,
+			BusChannel: m.opts.busChannel,
+			RequestID:  inv.RequestID,
+		}
+	}
+	return sent
+}
+
+// conversationType 撤回、消息扩展接口的会话类型
+func (t MessageTarget) conversationType() int {
+	switch t {
+	case MessageTargetPrivate:
+		return MessagePrivateType
+	case MessageTargetGroup:
+		return MessageGroupType
+	case MessageTargetChatRoom:
+		return 4
+	case MessageTargetSystem:
+		return 6
+	case MessageTargetUltraGroup:
+		return 10
+	}
+	return 0
+}
+
+// RecallSent 撤回发送的消息，按会话类型调用 PrivateRecall、GroupRecall、SystemRecall 或 ChatRoomRecall
+// 发送时间使用 sent.SentTime，服务端未返回发送时间时为本地时间，需要时请先设置为消息路由中的 MsgTimestamp
+func (rc *RongCloud) RecallSent(sent SentMessage, options ...MsgOption) error {
+	if sent.MessageUID == "" {
+		return RCErrorNew(1002, "Paramer 'messageUID' is required")
+	}
+	if sent.BusChannel != "" {
+		options = append([]MsgOption{WithMsgBusChannel(sent.BusChannel)}, options...)
+	}
+	sentTime := int(sent.SentTime)
+	switch sent.Target {
+	case MessageTargetPrivate:
+		return rc.PrivateRecall(sent.From, sent.TargetID, sent.MessageUID, sentTime, options...)
+	case MessageTargetGroup:
+		return rc.GroupRecall(sent.From, sent.TargetID, sent.MessageUID, sentTime, options...)
+	case MessageTargetSystem:
+		return rc.SystemRecall(sent.From, sent.TargetID, sent.MessageUID, sentTime, options...)
+	case MessageTargetChatRoom:
+		return rc.ChatRoomRecall(sent.From, sent.TargetID, sent.MessageUID, sentTime, options...)
+	}
+	return RCErrorNew(1002, "Paramer 'target' "+sent.Target.String()+" does not support recall")
+}
+
+// SetSentMessageExpansion 以发送人身份设置发送的消息的扩展，仅支持单聊、群聊，发送时需设置 Expansion(true, ...)
+func (rc *RongCloud) SetSentMessageExpansion(sent SentMessage, extra map[string]string, isSyncSender int) error {
+	if sent.MessageUID == "" {
+		return RCErrorNew(1002, "Paramer 'messageUID' is required")
+	}
+	if sent.Target != MessageTargetPrivate && sent.Target != MessageTargetGroup {
+		return RCErrorNew(1002, "Paramer 'target' "+sent.Target.String()+" does not support expansion")
+	}
+	return rc.SetMessageExpansion(sent.MessageUID, sent.From, strconv.Itoa(sent.Target.conversationType()),
+		sent.TargetID, extra, isSyncSender)
+}
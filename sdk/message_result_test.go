@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+func TestMessageRequest_Send_result(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	// 撤回、消息扩展接口由拦截器记录，不发送到模拟服务
+	var invs []*Invocation
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL),
+		WithInterceptors(func(inv *Invocation, next Invoker) error {
+			if strings.Contains(inv.Path, "/publish.") {
+				return next(inv)
+			}
+			invs = append(invs, inv)
+			inv.Response = []byte(`{"code":200}`)
+			return nil
+		}))
+
+	result, err := rc.NewMessage(MessageTargetPrivate).From("u01").To("u02", "u03").
+		Content(&TXTMsg{Content: "hello"}).Expansion(true, "").BusChannel("ch01").Send()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs := srv.Messages()
+	reqs := srv.Requests()
+	if len(result.Messages) != 2 || result.RequestID == "" || result.RequestID != reqs[len(reqs)-1].RequestID {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	for i, m := range result.Messages {
+		if m.Target != MessageTargetPrivate || m.From != "u01" || m.TargetID != msgs[0].To[i] ||
+			m.MessageUID != msgs[0].MessageUIDs[i] || m.SentTime == 0 || m.RequestID != result.RequestID {
+			t.Errorf("unexpected sent message %+v", m)
+		}
+	}
+	if uids := result.MessageUIDs(); uids["u03"] != msgs[0].MessageUIDs[1] {
+		t.Errorf("unexpected message UIDs %v", uids)
+	}
+
+	sent, ok := result.Message("u02")
+	if !ok {
+		t.Fatal("expect message for u02")
+	}
+	if err := rc.RecallSent(sent, WithIsDelete(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.SetSentMessageExpansion(sent, map[string]string{"k": "v"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 2 {
+		t.Fatalf("expect 2 requests, got %d", len(invs))
+	}
+	recall, expansion := invs[0].Params, invs[1].Params
+	if invs[0].Operation != "PrivateRecall" || recall.Get("fromUserId") != "u01" || recall.Get("targetId") != "u02" ||
+		recall.Get("messageUID") != sent.MessageUID || recall.Get("conversationType") != "1" ||
+		recall.Get("busChannel") != "ch01" || recall.Get("isDelete") != "1" || recall.Get("sentTime") == "0" {
+		t.Errorf("unexpected recall request %s %v", invs[0].Operation, recall)
+	}
+	if invs[1].Operation != "SetMessageExpansion" || expansion.Get("msgUID") != sent.MessageUID ||
+		expansion.Get("userId") != "u01" || expansion.Get("targetId") != "u02" || expansion.Get("conversationType") != "1" {
+		t.Errorf("unexpected expansion request %s %v", invs[1].Operation, expansion)
+	}
+
+	groupResult, err := rc.NewMessage(MessageTargetGroup).From("u01").To("g01").Content(&TXTMsg{Content: "hi"}).Send()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.RecallSent(groupResult.Messages[0]); err != nil {
+		t.Fatal(err)
+	}
+	if inv := invs[len(invs)-1]; inv.Operation != "GroupRecall" || inv.Params.Get("targetId") != "g01" || inv.Params.Get("conversationType") != "3" {
+		t.Errorf("unexpected group recall %s %v", inv.Operation, inv.Params)
+	}
+
+	if err := rc.RecallSent(SentMessage{Target: MessageTargetPrivate, From: "u01", TargetID: "u02"}); err == nil {
+		t.Error("expect error without messageUID")
+	}
+	if err := rc.SetSentMessageExpansion(SentMessage{Target: MessageTargetChatRoom, MessageUID: "M1"}, map[string]string{}, 0); err == nil {
+		t.Error("expect error for chatroom expansion")
+	}
+}
+
+func TestMessageRequest_Send_fanOutResult(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithFanOut(2))
+
+	users := fanOutUsers(2500)
+	srv.Inject(sdktest.Fault{Path: "/message/private/publish.json", Times: 1, Code: 1008})
+	result, err := rc.NewMessage(MessageTargetPrivate).From("u01").To(users...).Content(&TXTMsg{Content: "hi"}).Send()
+	if _, ok := err.(*BatchError); !ok {
+		t.Fatalf("expect *BatchError, got %v", err)
+	}
+	uids := map[string]string{}
+	for _, m := range srv.Messages() {
+		for i, id := range m.To {
+			uids[id] = m.MessageUIDs[i]
+		}
+	}
+	if len(result.Messages) != len(uids) || len(uids) < 1500 {
+		t.Fatalf("expect results of the successful batches, got %d of %d", len(result.Messages), len(uids))
+	}
+	for _, m := range result.Messages {
+		if m.MessageUID == "" || m.MessageUID != uids[m.TargetID] {
+			t.Errorf("unexpected sent message %+v", m)
+		}
+	}
+}
+
+func TestRongCloud_SendResObj(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", WithInterceptors(func(inv *Invocation, next Invoker) error {
+		invs = append(invs, inv)
+		inv.RequestID = "req-" + inv.Operation
+		inv.Response = []byte(`{"code":200,"messageUIDs":[{"userId":"u02","messageUID":"M1","sentTime":1700000000001},{"userId":"u03","messageUID":"M2"}]}`)
+		return nil
+	}))
+
+	result, err := rc.PrivateSendResObj("u01", []string{"u02", "u03"}, "", &TXTMsg{Content: "hi"}, "", "", 0, 0, 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequestID != "req-PrivateSend" || invs[0].Operation != "PrivateSend" {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if m, _ := result.Message("u02"); m.MessageUID != "M1" || m.SentTime != 1700000000001 {
+		t.Errorf("expect server sent time, got %+v", m)
+	}
+	if m, _ := result.Message("u03"); m.MessageUID != "M2" || m.SentTime == 0 || m.SentTime == 1700000000001 {
+		t.Errorf("expect local sent time, got %+v", m)
+	}
+
+	if result, err := rc.GroupSendResObj("u01", []string{"g01"}, nil, "", &TXTMsg{Content: "hi"}, "", "", 1, 0); err != nil || len(result.Messages) != 1 {
+		t.Errorf("unexpected group result %+v %v", result, err)
+	}
+	if result, err := rc.ChatRoomSendResObj("u01", []string{"c01"}, "", &TXTMsg{Content: "hi"}, 1, 0); err != nil || len(result.Messages) != 1 {
+		t.Errorf("unexpected chatroom result %+v %v", result, err)
+	}
+	if result, err := rc.SystemSendResObj("u01", []string{"u02"}, "", &TXTMsg{Content: "hi"}, "", "", 0, 1); err != nil || len(result.Messages) != 1 {
+		t.Errorf("unexpected system result %+v %v", result, err)
+	}
+	if len(invs) != 4 || invs[1].Operation != "GroupSend" || invs[2].Operation != "ChatRoomSend" || invs[3].Operation != "SystemSend" {
+		t.Errorf("unexpected requests %d", len(invs))
+	}
+}
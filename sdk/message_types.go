@@ -0,0 +1,313 @@
+package sdk
+
+import (
+	"encoding/json"
+)
+
+// ReferenceMsg 引用消息 RC:ReferenceMsg
+type ReferenceMsg struct {
+	Content        string          `json:"content"`        // 回复的文本内容
+	ReferMsgUserID string          `json:"referMsgUserId"` // 被引用消息的发送者 ID
+	ReferMsg       json.RawMessage `json:"referMsg"`       // 被引用消息的内容
+	ReferMsgUID    string          `json:"referMsgUid,omitempty"`
+	ObjName        string          `json:"objName"` // 被引用消息的 objectName
+	User           *MsgUserInfo    `json:"user,omitempty"`
+	Extra          string          `json:"extra,omitempty"`
+}
+
+// NewReferenceMsg 创建引用消息，referred 为被引用的消息，objectName 按 MessageRegistry 推断
+func NewReferenceMsg(content, referMsgUserID, referMsgUID string, referred rcMsg) (*ReferenceMsg, error) {
+	if referred == nil {
+		return nil, RCErrorNew(1002, "Paramer 'referMsg' is required")
+	}
+	objName, referMsg, err := EncodeMessage(referred)
+	if err != nil {
+		return nil, err
+	}
+	msg := &ReferenceMsg{
+		Content:        content,
+		ReferMsgUserID: referMsgUserID,
+		ReferMsg:       json.RawMessage(referMsg),
+		ReferMsgUID:    referMsgUID,
+		ObjName:        objName,
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *ReferenceMsg) Validate() error {
+	if msg.Content == "" {
+		return RCErrorNew(1002, "Paramer 'content' is required")
+	}
+	if msg.ReferMsgUserID == "" {
+		return RCErrorNew(1002, "Paramer 'referMsgUserId' is required")
+	}
+	if len(msg.ReferMsg) == 0 {
+		return RCErrorNew(1002, "Paramer 'referMsg' is required")
+	}
+	if msg.ObjName == "" {
+		return RCErrorNew(1002, "Paramer 'objName' is required")
+	}
+	return nil
+}
+
+// Referred 按 objName 解析被引用的消息
+func (msg *ReferenceMsg) Referred() (rcMsg, error) {
+	return DecodeMessage(msg.ObjName, string(msg.ReferMsg))
+}
+
+// ToString ReferenceMsg
+func (msg *ReferenceMsg) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// GIFMsg GIF 图片消息 RC:GIFMsg
+type GIFMsg struct {
+	GifDataSize int64        `json:"gifDataSize"` // 图片大小，字节
+	RemoteURL   string       `json:"remoteUrl"`
+	Width       int          `json:"width"`
+	Height      int          `json:"height"`
+	User        *MsgUserInfo `json:"user,omitempty"`
+	Extra       string       `json:"extra,omitempty"`
+}
+
+// NewGIFMsg 创建 GIF 图片消息
+func NewGIFMsg(remoteURL string, size int64, width, height int) (*GIFMsg, error) {
+	msg := &GIFMsg{GifDataSize: size, RemoteURL: remoteURL, Width: width, Height: height}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *GIFMsg) Validate() error {
+	if msg.RemoteURL == "" {
+		return RCErrorNew(1002, "Paramer 'remoteUrl' is required")
+	}
+	if msg.GifDataSize <= 0 {
+		return RCErrorNew(1002, "Paramer 'gifDataSize' is required")
+	}
+	if msg.Width <= 0 || msg.Height <= 0 {
+		return RCErrorNew(1002, "Paramer 'width' and 'height' are required")
+	}
+	return nil
+}
+
+// ToString GIFMsg
+func (msg *GIFMsg) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// SightMsg 小视频消息 RC:SightMsg
+type SightMsg struct {
+	SightURL string       `json:"sightUrl"`
+	Content  string       `json:"content"`  // 缩略图，Base64 编码的 JPG
+	Duration int          `json:"duration"` // 视频时长，秒
+	Size     int64        `json:"size"`     // 视频大小，字节
+	Name     string       `json:"name,omitempty"`
+	User     *MsgUserInfo `json:"user,omitempty"`
+	Extra    string       `json:"extra,omitempty"`
+}
+
+// NewSightMsg 创建小视频消息，thumbnail 为 Base64 编码的缩略图
+func NewSightMsg(sightURL, thumbnail string, duration int, size int64) (*SightMsg, error) {
+	msg := &SightMsg{SightURL: sightURL, Content: thumbnail, Duration: duration, Size: size}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *SightMsg) Validate() error {
+	if msg.SightURL == "" {
+		return RCErrorNew(1002, "Paramer 'sightUrl' is required")
+	}
+	if msg.Content == "" {
+		return RCErrorNew(1002, "Paramer 'content' is required")
+	}
+	if msg.Duration <= 0 {
+		return RCErrorNew(1002, "Paramer 'duration' is required")
+	}
+	if msg.Size <= 0 {
+		return RCErrorNew(1002, "Paramer 'size' is required")
+	}
+	return nil
+}
+
+// ToString SightMsg
+func (msg *SightMsg) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// CombineMsg 合并转发消息 RC:CombineMsg
+type CombineMsg struct {
+	RemoteURL        string       `json:"remoteUrl"`        // 合并转发内容的 HTML 文件地址
+	ConversationType int          `json:"conversationType"` // 1 单聊，3 群聊
+	NameList         []string     `json:"nameList"`         // 单聊时为双方的名称，群聊时为空
+	SummaryList      []string     `json:"summaryList"`      // 消息摘要，最多 4 条
+	User             *MsgUserInfo `json:"user,omitempty"`
+	Extra            string       `json:"extra,omitempty"`
+}
+
+// NewCombineMsg 创建合并转发消息
+func NewCombineMsg(remoteURL string, conversationType int, nameList, summaryList []string) (*CombineMsg, error) {
+	msg := &CombineMsg{
+		RemoteURL:        remoteURL,
+		ConversationType: conversationType,
+		NameList:         nameList,
+		SummaryList:      summaryList,
+	}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *CombineMsg) Validate() error {
+	if msg.RemoteURL == "" {
+		return RCErrorNew(1002, "Paramer 'remoteUrl' is required")
+	}
+	if msg.ConversationType != MessagePrivateType && msg.ConversationType != MessageGroupType {
+		return RCErrorNew(1002, "Paramer 'conversationType' must be 1 or 3")
+	}
+	if len(msg.SummaryList) == 0 {
+		return RCErrorNew(1002, "Paramer 'summaryList' is required")
+	}
+	return nil
+}
+
+// ToString CombineMsg
+func (msg *CombineMsg) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// RcCmdMsg 撤回命令消息 RC:RcCmd
+type RcCmdMsg struct {
+	MessageUID       string `json:"messageUId"` // 被撤回消息的 ID
+	ConversationType int    `json:"conversationType"`
+	TargetID         string `json:"targetId"`
+	IsAdmin          int    `json:"isAdmin"`  // 是否为管理员撤回，1 是，0 否
+	IsDelete         int    `json:"isDelete"` // 是否删除消息，1 是，0 否
+	Extra            string `json:"extra,omitempty"`
+}
+
+// NewRcCmdMsg 创建撤回命令消息
+func NewRcCmdMsg(messageUID string, conversationType int, targetID string) (*RcCmdMsg, error) {
+	msg := &RcCmdMsg{MessageUID: messageUID, ConversationType: conversationType, TargetID: targetID}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *RcCmdMsg) Validate() error {
+	if msg.MessageUID == "" {
+		return RCErrorNew(1002, "Paramer 'messageUId' is required")
+	}
+	if msg.ConversationType <= 0 {
+		return RCErrorNew(1002, "Paramer 'conversationType' is required")
+	}
+	if msg.TargetID == "" {
+		return RCErrorNew(1002, "Paramer 'targetId' is required")
+	}
+	return nil
+}
+
+// ToString RcCmdMsg
+func (msg *RcCmdMsg) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// ReadNtf 单聊已读回执消息 RC:ReadNtf
+type ReadNtf struct {
+	LastMessageSendTime int64  `json:"lastMessageSendTime"` // 最后一条已读消息的发送时间，毫秒
+	MessageUID          string `json:"messageUId,omitempty"`
+	Type                int    `json:"type"` // 固定为 1
+}
+
+// NewReadNtf 创建单聊已读回执消息
+func NewReadNtf(lastMessageSendTime int64, messageUID string) (*ReadNtf, error) {
+	msg := &ReadNtf{LastMessageSendTime: lastMessageSendTime, MessageUID: messageUID, Type: 1}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *ReadNtf) Validate() error {
+	if msg.LastMessageSendTime <= 0 {
+		return RCErrorNew(1002, "Paramer 'lastMessageSendTime' is required")
+	}
+	if msg.Type != 1 {
+		return RCErrorNew(1002, "Paramer 'type' must be 1")
+	}
+	return nil
+}
+
+// ToString ReadNtf
+func (msg *ReadNtf) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// TypSts 输入状态消息 RC:TypSts
+type TypSts struct {
+	TypingContentType string `json:"typingContentType"` // 正在输入的消息类型，如 RC:TxtMsg
+}
+
+// NewTypSts 创建输入状态消息
+func NewTypSts(typingContentType string) (*TypSts, error) {
+	msg := &TypSts{TypingContentType: typingContentType}
+	if err := msg.Validate(); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Validate 检查必填字段
+func (msg *TypSts) Validate() error {
+	if msg.TypingContentType == "" {
+		return RCErrorNew(1002, "Paramer 'typingContentType' is required")
+	}
+	return nil
+}
+
+// ToString TypSts
+func (msg *TypSts) ToString() (string, error) {
+	bytes, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// 文档中的示例消息内容
+var messageTypeSamples = map[string]string{
+	"RC:ReferenceMsg": `{"content":"回复内容","referMsgUserId":"userA","referMsg":{"content":"被引用的内容","extra":""},"referMsgUid":"BS45-NPH4-HV87-10LM","objName":"RC:TxtMsg","user":{"id":"userB","name":"B","icon":"","portrait":"","extra":""}}`,
+	"RC:GIFMsg":       `{"gifDataSize":34302,"remoteUrl":"https://rongcloud-image.cn.ronghub.com/image.gif","width":64,"height":64,"extra":"gif"}`,
+	"RC:SightMsg":     `{"sightUrl":"https://rongcloud-file.cn.ronghub.com/video.mp4","content":"/9j/4AAQSkZJRgABAQ","duration":5,"size":20480,"name":"video.mp4"}`,
+	"RC:CombineMsg":   `{"remoteUrl":"https://rongcloud-file.cn.ronghub.com/combine.html","conversationType":1,"nameList":["A","B"],"summaryList":["A: 你好","B: 你好"]}`,
+	"RC:RcCmd":        `{"messageUId":"BS45-NPH4-HV87-10LM","conversationType":1,"targetId":"userB","isAdmin":0,"isDelete":1}`,
+	"RC:ReadNtf":      `{"lastMessageSendTime":1597049286537,"messageUId":"BS45-NPH4-HV87-10LM","type":1}`,
+	"RC:TypSts":       `{"typingContentType":"RC:TxtMsg"}`,
+}
+
+func TestMessageTypes_roundTrip(t *testing.T) {
+	for objectName, sample := range messageTypeSamples {
+		msg, err := DecodeMessage(objectName, sample)
+		if err != nil {
+			t.Fatalf("%s: %v", objectName, err)
+		}
+		if err := msg.(interface{ Validate() error }).Validate(); err != nil {
+			t.Errorf("%s: sample should be valid: %v", objectName, err)
+		}
+		name, content, err := EncodeMessage(msg)
+		if err != nil || name != objectName {
+			t.Fatalf("%s: unexpected encode result %s %v", objectName, name, err)
+		}
+		var expect, got map[string]interface{}
+		if err := json.Unmarshal([]byte(sample), &expect); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(content), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expect, got) {
+			t.Errorf("%s: round trip mismatch\nexpect %s\ngot    %s", objectName, sample, content)
+		}
+	}
+}
+
+func TestNewReferenceMsg(t *testing.T) {
+	msg, err := NewReferenceMsg("回复内容", "userA", "BS45-NPH4-HV87-10LM", &ImgMsg{ImageURI: "https://example.com/a.png"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.ObjName != "RC:ImgMsg" {
+		t.Errorf("unexpected objName %s", msg.ObjName)
+	}
+	referred, err := msg.Referred()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img, ok := referred.(*ImgMsg); !ok || img.ImageURI != "https://example.com/a.png" {
+		t.Errorf("unexpected referred message %#v", referred)
+	}
+
+	if _, err := NewReferenceMsg("", "userA", "", &TXTMsg{}); err == nil {
+		t.Error("expect error for empty content")
+	}
+	if _, err := NewReferenceMsg("回复内容", "userA", "", nil); err == nil {
+		t.Error("expect error for nil referMsg")
+	}
+}
+
+func TestMessageTypes_validate(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"gif without url", second(NewGIFMsg("", 100, 10, 10))},
+		{"gif without size", second(NewGIFMsg("https://example.com/a.gif", 0, 10, 10))},
+		{"sight without thumbnail", second(NewSightMsg("https://example.com/a.mp4", "", 5, 100))},
+		{"sight without duration", second(NewSightMsg("https://example.com/a.mp4", "/9j/", 0, 100))},
+		{"combine with bad conversation type", second(NewCombineMsg("https://example.com/a.html", 2, nil, []string{"A: hi"}))},
+		{"combine without summary", second(NewCombineMsg("https://example.com/a.html", 3, nil, nil))},
+		{"rccmd without messageUId", second(NewRcCmdMsg("", 1, "userB"))},
+		{"rccmd without targetId", second(NewRcCmdMsg("BS45", 1, ""))},
+		{"readntf without time", second(NewReadNtf(0, ""))},
+		{"typsts without type", second(NewTypSts(""))},
+	}
+	for _, c := range cases {
+		if c.err == nil {
+			t.Errorf("%s: expect error", c.name)
+		}
+	}
+
+	if msg, err := NewReadNtf(1597049286537, ""); err != nil || msg.Type != 1 {
+		t.Errorf("unexpected ReadNtf %+v %v", msg, err)
+	}
+	if _, err := NewCombineMsg("https://example.com/a.html", 3, nil, []string{"A: hi"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func second[T any](_ T, err error) error {
+	return err
+}
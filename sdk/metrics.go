@@ -0,0 +1,218 @@
+package sdk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// MetricRequests 请求次数（含重试），counter
+	MetricRequests = "rongcloud_requests_total"
+	// MetricRequestErrors 失败的请求次数，网络错误时 status 为 0，业务错误时 code 为业务返回码，counter
+	MetricRequestErrors = "rongcloud_request_errors_total"
+	// MetricRequestDuration 单次请求耗时，单位秒，histogram
+	MetricRequestDuration = "rongcloud_request_duration_seconds"
+	// MetricRetries 重试次数，counter
+	MetricRetries = "rongcloud_retries_total"
+	// MetricFailovers 域名切换次数，endpoint 为暂停使用的地址，counter
+	MetricFailovers = "rongcloud_endpoint_failovers_total"
+)
+
+// metricHelp 指标说明
+var metricHelp = map[string]string{
+	MetricRequests:        "Total number of requests sent to RongCloud, including retries.",
+	MetricRequestErrors:   "Total number of failed requests. status is 0 for network errors.",
+	MetricRequestDuration: "Duration of a single request to RongCloud in seconds.",
+	MetricRetries:         "Total number of retried requests.",
+	MetricFailovers:       "Total number of times an endpoint was taken out of service.",
+}
+
+// MetricLabels 指标标签
+type MetricLabels struct {
+	Operation string // 接口方法名，如 PrivateSend
+	Endpoint  string // Api 地址
+	Status    int    // http 状态码，网络错误时为 0
+	Code      int    // 业务返回码，无法解析时为 0
+}
+
+// Metrics 指标接口，SDK 在每次请求、重试和域名切换时上报
+type Metrics interface {
+	// Add counter 增加 delta
+	Add(name string, labels MetricLabels, delta float64)
+	// Observe histogram 记录一个样本
+	Observe(name string, labels MetricLabels, value float64)
+}
+
+// nopMetrics 默认的指标实现，不做任何记录
+type nopMetrics struct{}
+
+func (nopMetrics) Add(string, MetricLabels, float64)     {}
+func (nopMetrics) Observe(string, MetricLabels, float64) {}
+
+// WithMetrics 设置指标上报，默认不上报
+func WithMetrics(metrics Metrics) rongCloudOption {
+	return func(o *RongCloud) {
+		if metrics == nil {
+			metrics = nopMetrics{}
+		}
+		o.metrics = metrics
+	}
+}
+
+// DefaultMetricBuckets 默认的耗时 histogram 分桶，单位秒
+var DefaultMetricBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type metricKey struct {
+	name   string
+	labels MetricLabels
+}
+
+type histogram struct {
+	counts []uint64 // 与 buckets 一一对应，不累加
+	count  uint64
+	sum    float64
+}
+
+// MemoryMetrics 内存中的指标实现，可以按 Prometheus 文本格式输出
+type MemoryMetrics struct {
+	mu         sync.Mutex
+	buckets    []float64
+	counters   map[metricKey]float64
+	histograms map[metricKey]*histogram
+}
+
+// NewMemoryMetrics 创建内存指标，buckets 为 histogram 分桶上限，为空时使用 DefaultMetricBuckets
+func NewMemoryMetrics(buckets ...float64) *MemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &MemoryMetrics{
+		buckets:    buckets,
+		counters:   map[metricKey]float64{},
+		histograms: map[metricKey]*histogram{},
+	}
+}
+
+// Add counter 增加 delta
+func (m *MemoryMetrics) Add(name string, labels MetricLabels, delta float64) {
+	m.mu.Lock()
+	m.counters[metricKey{name, labels}] += delta
+	m.mu.Unlock()
+}
+
+// Observe histogram 记录一个样本
+func (m *MemoryMetrics) Observe(name string, labels MetricLabels, value float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := metricKey{name, labels}
+	h := m.histograms[key]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.histograms[key] = h
+	}
+	if i := sort.SearchFloat64s(m.buckets, value); i < len(m.buckets) {
+		h.counts[i]++
+	}
+	h.count++
+	h.sum += value
+}
+
+// Counter 获取 counter 当前值
+func (m *MemoryMetrics) Counter(name string, labels MetricLabels) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.counters[metricKey{name, labels}]
+}
+
+// WritePrometheus 按 Prometheus 文本格式输出所有指标
+func (m *MemoryMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	counters := make([]metricKey, 0, len(m.counters))
+	for key := range m.counters {
+		counters = append(counters, key)
+	}
+	sortMetricKeys(counters)
+	for i, key := range counters {
+		if i == 0 || counters[i-1].name != key.name {
+			writeMetricHeader(bw, key.name, "counter")
+		}
+		fmt.Fprintf(bw, "%s{%s} %s\n", key.name, formatMetricLabels(key.labels), formatMetricValue(m.counters[key]))
+	}
+
+	histograms := make([]metricKey, 0, len(m.histograms))
+	for key := range m.histograms {
+		histograms = append(histograms, key)
+	}
+	sortMetricKeys(histograms)
+	for i, key := range histograms {
+		if i == 0 || histograms[i-1].name != key.name {
+			writeMetricHeader(bw, key.name, "histogram")
+		}
+		h := m.histograms[key]
+		labels := formatMetricLabels(key.labels)
+		var cumulative uint64
+		for j, le := range m.buckets {
+			cumulative += h.counts[j]
+			fmt.Fprintf(bw, "%s_bucket{%s,le=\"%s\"} %d\n", key.name, labels, formatMetricValue(le), cumulative)
+		}
+		fmt.Fprintf(bw, "%s_bucket{%s,le=\"+Inf\"} %d\n", key.name, labels, h.count)
+		fmt.Fprintf(bw, "%s_sum{%s} %s\n", key.name, labels, formatMetricValue(h.sum))
+		fmt.Fprintf(bw, "%s_count{%s} %d\n", key.name, labels, h.count)
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP 输出 Prometheus 文本格式的指标，可直接挂载到 /metrics
+func (m *MemoryMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+func writeMetricHeader(w io.Writer, name, typ string) {
+	if help, ok := metricHelp[name]; ok {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+func sortMetricKeys(keys []metricKey) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.labels.Operation != b.labels.Operation {
+			return a.labels.Operation < b.labels.Operation
+		}
+		if a.labels.Endpoint != b.labels.Endpoint {
+			return a.labels.Endpoint < b.labels.Endpoint
+		}
+		if a.labels.Status != b.labels.Status {
+			return a.labels.Status < b.labels.Status
+		}
+		return a.labels.Code < b.labels.Code
+	})
+}
+
+var metricLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatMetricLabels(labels MetricLabels) string {
+	return fmt.Sprintf(`operation="%s",endpoint="%s",status="%d",code="%d"`,
+		metricLabelEscaper.Replace(labels.Operation), metricLabelEscaper.Replace(labels.Endpoint),
+		labels.Status, labels.Code)
+}
+
+func formatMetricValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryMetrics_WritePrometheus(t *testing.T) {
+	m := NewMemoryMetrics(0.1, 1)
+	labels := MetricLabels{Operation: "PrivateSend", Endpoint: `http://a"b`, Status: 200, Code: 200}
+	m.Add(MetricRequests, labels, 1)
+	m.Add(MetricRequests, labels, 1)
+	m.Observe(MetricRequestDuration, labels, 0.05)
+	m.Observe(MetricRequestDuration, labels, 0.5)
+	m.Observe(MetricRequestDuration, labels, 5)
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		t.Fatal(err)
+	}
+	lbs := `operation="PrivateSend",endpoint="http://a\"b",status="200",code="200"`
+	expected := []string{
+		"# TYPE rongcloud_requests_total counter",
+		"rongcloud_requests_total{" + lbs + "} 2",
+		"# TYPE rongcloud_request_duration_seconds histogram",
+		"rongcloud_request_duration_seconds_bucket{" + lbs + `,le="0.1"} 1`,
+		"rongcloud_request_duration_seconds_bucket{" + lbs + `,le="1"} 2`,
+		"rongcloud_request_duration_seconds_bucket{" + lbs + `,le="+Inf"} 3`,
+		"rongcloud_request_duration_seconds_sum{" + lbs + "} 5.55",
+		"rongcloud_request_duration_seconds_count{" + lbs + "} 3",
+	}
+	out := buf.String()
+	for _, line := range expected {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("missing %q in\n%s", line, out)
+		}
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":1002,"errorMessage":"userId is required"}`))
+	}))
+	defer good.Close()
+
+	m := NewMemoryMetrics()
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	rc := NewRongCloud("key", "secret", WithRongCloudURIs(bad.URL, good.URL), WithRetryPolicy(policy), WithMetrics(m))
+	if err, _ := rc.UGGroupCreate("u01", "g01", "group"); err == nil {
+		t.Fatal("expect business error")
+	}
+
+	failed := MetricLabels{Operation: "UGGroupCreate", Endpoint: bad.URL, Status: http.StatusServiceUnavailable}
+	business := MetricLabels{Operation: "UGGroupCreate", Endpoint: good.URL, Status: http.StatusOK, Code: 1002}
+	cases := []struct {
+		name   string
+		labels MetricLabels
+		value  float64
+	}{
+		{MetricRequests, failed, 1},
+		{MetricRequestErrors, failed, 1},
+		{MetricRetries, failed, 1},
+		{MetricRequests, business, 1},
+		{MetricRequestErrors, business, 1},
+		{MetricFailovers, MetricLabels{Endpoint: bad.URL}, 1},
+	}
+	for _, c := range cases {
+		if v := m.Counter(c.name, c.labels); v != c.value {
+			t.Errorf("%s%+v: expect %v, got %v", c.name, c.labels, c.value, v)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), "rongcloud_endpoint_failovers_total") {
+		t.Errorf("unexpected metrics output\n%s", rec.Body.String())
+	}
+}
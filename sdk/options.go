@@ -21,6 +21,34 @@ func WithRongCloudURI(rongCloudURI string) rongCloudOption {
 	}
 }
 
+// WithRongCloudURIs 设置多个融云 Api 地址，按顺序优先使用可用的地址
+// 请求失败的地址会暂停使用，冷却结束并探测成功后恢复，适用于部署了多个网关的私有云
+func WithRongCloudURIs(uris ...string) rongCloudOption {
+	return func(o *RongCloud) {
+		if len(uris) == 0 {
+			return
+		}
+		o.rongCloudURI = uris[0]
+		o.rongCloudURIs = uris
+	}
+}
+
+// WithEndpointHealth 设置地址池健康检查参数
+// 地址连续失败 maxFailures 次后暂停使用 cooldown 时间，默认失败 1 次暂停 30 秒
+func WithEndpointHealth(maxFailures int, cooldown time.Duration) rongCloudOption {
+	return func(o *RongCloud) {
+		o.endpointMaxFailures = maxFailures
+		o.endpointCooldown = cooldown
+	}
+}
+
+// WithEndpointProbe 设置地址冷却结束后的探测方法，默认发送 HEAD 请求，非 5xx 即视为可用
+func WithEndpointProbe(probe EndpointProbe) rongCloudOption {
+	return func(o *RongCloud) {
+		o.endpointProbe = probe
+	}
+}
+
 // WithTimeout 设置超时时间，最小单位为秒
 func WithTimeout(t time.Duration) rongCloudOption {
 	return func(o *RongCloud) {
@@ -42,8 +70,24 @@ func WithMaxIdleConnsPerHost(n int) rongCloudOption {
 	}
 }
 
+// WithRetryPolicy 设置请求重试策略，默认不重试
+// 网络错误或 5xx 时会自动切换到备用域名再重试，v2 接口重试时使用相同的 RC-Request-Id
+func WithRetryPolicy(policy RetryPolicy) rongCloudOption {
+	return func(o *RongCloud) {
+		o.retryPolicy = policy
+	}
+}
+
 func WithTransport(transport http.RoundTripper) rongCloudOption {
 	return func(o *RongCloud) {
 		o.globalTransport = transport
 	}
 }
+
+// WithHTTPClient 使用自定义的 *http.Client 发送请求，重定向、cookie、代理等按 client 的配置处理
+// 设置后 WithTransport、SetHttpTransport 不再生效；请求超时仍以 WithTimeout 或 ctx 为准，client.Timeout 同样生效
+func WithHTTPClient(client *http.Client) rongCloudOption {
+	return func(o *RongCloud) {
+		o.httpClient = client
+	}
+}
@@ -0,0 +1,195 @@
+package sdk
+
+// 分页接口每页条数的上限，Pager 的 size 超出上限或小于等于 0 时使用上限
+const (
+	UG_USER_GROUPS_MAX_SIZE     = 100  // UGQueryUserGroups
+	UG_GROUP_USERS_MAX_SIZE     = 100  // UGQueryGroupUsers
+	UG_CHANNEL_MAX_SIZE         = 100  // UGChannelQuery
+	UG_USER_BANNED_MAX_SIZE     = 200  // UltraGroupUserBannedGet
+	UG_USER_GROUP_MAX_SIZE      = 50   // UGUserGroupQuery
+	USER_DEACTIVATE_MAX_SIZE    = 50   // UserDeactivateQuery
+	USER_REMARKS_MAX_SIZE       = 50   // UserRemarksGetResObj
+	CHATROOM_BAN_QUERY_MAX_SIZE = 1000 // ChatRoomBanQuery
+)
+
+// PageFunc 查询第 page 页（从 1 开始），每页 size 条
+type PageFunc[T any] func(page, size int) ([]T, error)
+
+type pageResult[T any] struct {
+	items []T
+	err   error
+}
+
+// Pager 按页遍历分页接口，某一页不足 size 条或为空时结束
+//
+//	pager := rc.UGQueryGroupUsersPager("ug01", 100)
+//	for pager.Next() {
+//		for _, user := range pager.Page() {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type Pager[T any] struct {
+	fetch    PageFunc[T]
+	size     int
+	page     int
+	items    []T
+	err      error
+	done     bool
+	prefetch bool
+	next     chan pageResult[T]
+}
+
+// NewPager 创建 Pager，size 为每页条数，小于等于 0 时为 1
+func NewPager[T any](size int, fetch PageFunc[T]) *Pager[T] {
+	if size <= 0 {
+		size = 1
+	}
+	return &Pager[T]{fetch: fetch, size: size}
+}
+
+// Prefetch 开启预取，处理当前页时在后台查询下一页
+func (p *Pager[T]) Prefetch() *Pager[T] {
+	p.prefetch = true
+	return p
+}
+
+// load 在后台查询第 page 页
+func (p *Pager[T]) load(page int) chan pageResult[T] {
+	ch := make(chan pageResult[T], 1)
+	go func() {
+		items, err := p.fetch(page, p.size)
+		ch <- pageResult[T]{items: items, err: err}
+	}()
+	return ch
+}
+
+// Next 查询下一页，没有更多数据或查询出错时返回 false
+func (p *Pager[T]) Next() bool {
+	if p.done {
+		p.items = nil
+		return false
+	}
+	var res pageResult[T]
+	if p.next != nil {
+		res = <-p.next
+		p.next = nil
+	} else {
+		res.items, res.err = p.fetch(p.page+1, p.size)
+	}
+	p.page++
+	if res.err != nil {
+		p.err, p.done, p.items = res.err, true, nil
+		return false
+	}
+	if len(res.items) < p.size {
+		p.done = true
+	}
+	if len(res.items) == 0 {
+		p.items = nil
+		return false
+	}
+	p.items = res.items
+	if !p.done && p.prefetch {
+		p.next = p.load(p.page + 1)
+	}
+	return true
+}
+
+// Page 当前页的数据
+func (p *Pager[T]) Page() []T {
+	return p.items
+}
+
+// PageNo 当前页的页数，从 1 开始
+func (p *Pager[T]) PageNo() int {
+	return p.page
+}
+
+// Err 查询出错时返回错误
+func (p *Pager[T]) Err() error {
+	return p.err
+}
+
+// All 查询剩余的所有数据
+func (p *Pager[T]) All() ([]T, error) {
+	var all []T
+	for p.Next() {
+		all = append(all, p.items...)
+	}
+	return all, p.err
+}
+
+// pageSize size 小于等于 0 或超出上限时使用上限
+func pageSize(size, max int) int {
+	if size <= 0 || size > max {
+		return max
+	}
+	return size
+}
+
+// UGQueryUserGroupsPager 分页遍历用户所在超级群，size 最大 100
+func (rc *RongCloud) UGQueryUserGroupsPager(userId string, size int) *Pager[UGGroupInfo] {
+	return NewPager(pageSize(size, UG_USER_GROUPS_MAX_SIZE), func(page, size int) ([]UGGroupInfo, error) {
+		groups, err, _ := rc.UGQueryUserGroups(userId, page, size)
+		return groups, err
+	})
+}
+
+// UGQueryGroupUsersPager 分页遍历超级群成员，size 最大 100
+func (rc *RongCloud) UGQueryGroupUsersPager(groupId string, size int) *Pager[UGUserInfo] {
+	return NewPager(pageSize(size, UG_GROUP_USERS_MAX_SIZE), func(page, size int) ([]UGUserInfo, error) {
+		users, err, _ := rc.UGQueryGroupUsers(groupId, page, size)
+		return users, err
+	})
+}
+
+// UGChannelQueryPager 分页遍历超级群频道，size 最大 100
+func (rc *RongCloud) UGChannelQueryPager(groupId string, size int) *Pager[UGChannelInfo] {
+	return NewPager(pageSize(size, UG_CHANNEL_MAX_SIZE), func(page, size int) ([]UGChannelInfo, error) {
+		channels, err, _ := rc.UGChannelQuery(groupId, page, size)
+		return channels, err
+	})
+}
+
+// UltraGroupUserBannedPager 分页遍历超级群禁言成员，size 最大 200
+func (rc *RongCloud) UltraGroupUserBannedPager(groupId, busChannel string, size int) *Pager[UltraGroupUserBannedResponseItem] {
+	return NewPager(pageSize(size, UG_USER_BANNED_MAX_SIZE), func(page, size int) ([]UltraGroupUserBannedResponseItem, error) {
+		return rc.UltraGroupUserBannedGet(groupId, busChannel, page, size)
+	})
+}
+
+// UGUserGroupQueryPager 分页遍历超级群用户组，size 最大 50
+func (rc *RongCloud) UGUserGroupQueryPager(groupId string, size int) *Pager[UGUserGroupInfo] {
+	return NewPager(pageSize(size, UG_USER_GROUP_MAX_SIZE), func(page, size int) ([]UGUserGroupInfo, error) {
+		return rc.UGUserGroupQuery(groupId, page, size)
+	})
+}
+
+// UserDeactivatePager 分页遍历已注销用户，size 最大 50
+func (rc *RongCloud) UserDeactivatePager(size int) *Pager[string] {
+	return NewPager(pageSize(size, USER_DEACTIVATE_MAX_SIZE), func(page, size int) ([]string, error) {
+		resp, err := rc.UserDeactivateQuery(page, size)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Users, nil
+	})
+}
+
+// UserRemarksPager 分页遍历用户级推送备注名，size 最大 50
+func (rc *RongCloud) UserRemarksPager(userId string, size int) *Pager[UserRemarksUsers] {
+	return NewPager(pageSize(size, USER_REMARKS_MAX_SIZE), func(page, size int) ([]UserRemarksUsers, error) {
+		resp, err := rc.UserRemarksGetResObj(userId, page, size)
+		return resp.Users, err
+	})
+}
+
+// ChatRoomBanPager 分页遍历全体禁言的聊天室，size 最大 1000
+func (rc *RongCloud) ChatRoomBanPager(size int) *Pager[string] {
+	return NewPager(pageSize(size, CHATROOM_BAN_QUERY_MAX_SIZE), func(page, size int) ([]string, error) {
+		return rc.ChatRoomBanQuery(size, page)
+	})
+}
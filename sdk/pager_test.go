@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+// pageOf 模拟共 total 条数据的分页接口
+func pageOf(total int, calls *int32) PageFunc[int] {
+	return func(page, size int) ([]int, error) {
+		atomic.AddInt32(calls, 1)
+		var items []int
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestPager(t *testing.T) {
+	cases := []struct {
+		total, size int
+		calls       int32
+	}{
+		{0, 10, 1},
+		{5, 10, 1},
+		{25, 10, 3},
+		{30, 10, 4}, // 最后一页刚好满，需要多查询一次空页
+	}
+	for _, c := range cases {
+		for _, prefetch := range []bool{false, true} {
+			var calls int32
+			p := NewPager(c.size, pageOf(c.total, &calls))
+			if prefetch {
+				p.Prefetch()
+			}
+			all, err := p.All()
+			if err != nil || len(all) != c.total {
+				t.Errorf("total %d prefetch %v: got %d items, %v", c.total, prefetch, len(all), err)
+			}
+			for i, v := range all {
+				if v != i {
+					t.Fatalf("total %d prefetch %v: unexpected item %d at %d", c.total, prefetch, v, i)
+				}
+			}
+			if n := atomic.LoadInt32(&calls); n != c.calls {
+				t.Errorf("total %d prefetch %v: expect %d calls, got %d", c.total, prefetch, c.calls, n)
+			}
+			if p.Next() {
+				t.Errorf("total %d: Next should return false after the last page", c.total)
+			}
+		}
+	}
+}
+
+func TestPager_error(t *testing.T) {
+	failed := errors.New("failed")
+	p := NewPager(2, func(page, size int) ([]string, error) {
+		if page == 2 {
+			return nil, failed
+		}
+		return []string{"a", "b"}, nil
+	})
+	if !p.Next() || len(p.Page()) != 2 || p.PageNo() != 1 {
+		t.Fatalf("unexpected first page %v", p.Page())
+	}
+	if p.Next() || !errors.Is(p.Err(), failed) {
+		t.Errorf("expect error, got %v", p.Err())
+	}
+	if p.Next() {
+		t.Error("Next should return false after error")
+	}
+}
+
+func TestRongCloud_UGQueryGroupUsersPager(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL))
+
+	if err, _ := rc.UGGroupCreate("u0", "ug01", "ultra"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < 25; i++ {
+		if err := rc.UltraGroupJoin("u"+strconv.Itoa(i), "ug01"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	users, err := rc.UGQueryGroupUsersPager("ug01", 10).Prefetch().All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 25 || users[0].Id != "u0" || users[24].Id != "u24" {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
@@ -0,0 +1,204 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+const (
+	PresenceOnline  = 0 // PresenceOnline 上线
+	PresenceOffline = 1 // PresenceOffline 离线
+	PresenceLogout  = 2 // PresenceLogout 登出
+)
+
+// PresenceEvent 用户在线状态订阅推送的一条状态变化
+type PresenceEvent struct {
+	UserID   string `json:"userid"`
+	Status   int    `json:"status"` // PresenceOnline、PresenceOffline 或 PresenceLogout
+	OS       string `json:"os"`     // 登录平台，如 iOS、Android、Websocket、PC
+	Time     int64  `json:"time"`   // 状态变化的时间，毫秒
+	ClientIP string `json:"clientIp"`
+}
+
+// presenceEventJSON status、time 可能是字符串
+type presenceEventJSON struct {
+	UserID   string      `json:"userid"`
+	Status   json.Number `json:"status"`
+	OS       string      `json:"os"`
+	Time     json.Number `json:"time"`
+	ClientIP string      `json:"clientIp"`
+}
+
+// ParsePresenceEvents 解析在线状态订阅推送，请求体为 JSON 数组或单个 JSON 对象
+func ParsePresenceEvents(r *http.Request) ([]PresenceEvent, error) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	var raws []presenceEventJSON
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &raws)
+	} else {
+		raws = make([]presenceEventJSON, 1)
+		err = json.Unmarshal(data, &raws[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]PresenceEvent, 0, len(raws))
+	for _, raw := range raws {
+		if raw.UserID == "" {
+			return nil, RCErrorNew(1002, "Paramer 'userid' is required")
+		}
+		status, err := raw.Status.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid status %s", raw.Status)
+		}
+		ts, err := raw.Time.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %s", raw.Time)
+		}
+		events = append(events, PresenceEvent{
+			UserID:   raw.UserID,
+			Status:   int(status),
+			OS:       raw.OS,
+			Time:     ts,
+			ClientIP: raw.ClientIP,
+		})
+	}
+	return events, nil
+}
+
+// PresenceTable 内存中的用户在线状态表，按用户和登录平台记录最新的状态
+// 同一用户同一平台时间不晚于已记录状态的事件视为重复或乱序，不会覆盖已记录的状态
+type PresenceTable struct {
+	mu    sync.RWMutex
+	users map[string]map[string]PresenceEvent // userID -> os -> event
+}
+
+// NewPresenceTable 创建在线状态表
+func NewPresenceTable() *PresenceTable {
+	return &PresenceTable{users: map[string]map[string]PresenceEvent{}}
+}
+
+// Update 记录状态变化，事件重复或比已记录的状态旧时返回 false
+func (t *PresenceTable) Update(event PresenceEvent) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	devices, ok := t.users[event.UserID]
+	if !ok {
+		devices = map[string]PresenceEvent{}
+		t.users[event.UserID] = devices
+	}
+	if last, ok := devices[event.OS]; ok && event.Time <= last.Time {
+		return false
+	}
+	devices[event.OS] = event
+	return true
+}
+
+// Online 用户是否在任一平台在线，ok 为 false 表示没有收到过该用户的状态
+func (t *PresenceTable) Online(userID string) (online, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	devices, ok := t.users[userID]
+	if !ok {
+		return false, false
+	}
+	for _, e := range devices {
+		if e.Status == PresenceOnline {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// OnlineStatus 与 OnlineStatusCheck 返回值一致的在线状态，1 在线，0 不在线
+// ok 为 false 时表中没有该用户，需要调用 OnlineStatusCheck 查询
+func (t *PresenceTable) OnlineStatus(userID string) (status int, ok bool) {
+	online, ok := t.Online(userID)
+	if online {
+		return 1, ok
+	}
+	return 0, ok
+}
+
+// Devices 用户各登录平台最新的状态
+func (t *PresenceTable) Devices(userID string) []PresenceEvent {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	devices := make([]PresenceEvent, 0, len(t.users[userID]))
+	for _, e := range t.users[userID] {
+		devices = append(devices, e)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].OS < devices[j].OS })
+	return devices
+}
+
+// Remove 删除用户的在线状态
+func (t *PresenceTable) Remove(userID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.users, userID)
+}
+
+// PresenceHandler 用户在线状态订阅的 http.Handler，将推送的状态变化记录到 PresenceTable
+type PresenceHandler struct {
+	verifier *WebhookVerifier
+	table    *PresenceTable
+	onChange func(ctx context.Context, event PresenceEvent) error
+}
+
+// NewPresenceHandler 创建在线状态订阅处理器，verifier 不为 nil 时先校验签名，校验失败响应 401
+// table 为 nil 时使用 NewPresenceTable()
+func NewPresenceHandler(verifier *WebhookVerifier, table *PresenceTable) *PresenceHandler {
+	if table == nil {
+		table = NewPresenceTable()
+	}
+	return &PresenceHandler{verifier: verifier, table: table}
+}
+
+// Table 记录状态的在线状态表
+func (h *PresenceHandler) Table() *PresenceTable {
+	return h.table
+}
+
+// OnChange 设置状态变化的回调，重复和乱序的事件不会回调，返回错误时响应 500
+func (h *PresenceHandler) OnChange(fn func(ctx context.Context, event PresenceEvent) error) {
+	h.onChange = fn
+}
+
+// ServeHTTP 解析推送的状态变化，按时间顺序更新在线状态表
+func (h *PresenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.verifier != nil {
+		if err := h.verifier.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	events, err := ParsePresenceEvents(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// 同一批次中的事件可能乱序
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time < events[j].Time })
+	for _, e := range events {
+		if !h.table.Update(e) || h.onChange == nil {
+			continue
+		}
+		if err := h.onChange(r.Context(), e); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPresenceTable(t *testing.T) {
+	table := NewPresenceTable()
+	if _, ok := table.OnlineStatus("u01"); ok {
+		t.Fatal("unknown user should not be ok")
+	}
+	if !table.Update(PresenceEvent{UserID: "u01", Status: PresenceOnline, OS: "iOS", Time: 100}) {
+		t.Fatal("first event should be accepted")
+	}
+	if table.Update(PresenceEvent{UserID: "u01", Status: PresenceOffline, OS: "iOS", Time: 90}) {
+		t.Error("stale event should be dropped")
+	}
+	if table.Update(PresenceEvent{UserID: "u01", Status: PresenceOnline, OS: "iOS", Time: 100}) {
+		t.Error("duplicate event should be dropped")
+	}
+	table.Update(PresenceEvent{UserID: "u01", Status: PresenceOffline, OS: "Android", Time: 200})
+	if status, ok := table.OnlineStatus("u01"); !ok || status != 1 {
+		t.Errorf("expect online on iOS, got %d %v", status, ok)
+	}
+	table.Update(PresenceEvent{UserID: "u01", Status: PresenceLogout, OS: "iOS", Time: 300})
+	if online, ok := table.Online("u01"); !ok || online {
+		t.Errorf("expect offline, got %v %v", online, ok)
+	}
+	if devices := table.Devices("u01"); len(devices) != 2 || devices[0].OS != "Android" {
+		t.Errorf("unexpected devices %+v", devices)
+	}
+}
+
+func TestPresenceHandler(t *testing.T) {
+	h := NewPresenceHandler(NewWebhookVerifier("key", "secret"), nil)
+	var changes []PresenceEvent
+	h.OnChange(func(ctx context.Context, event PresenceEvent) error {
+		changes = append(changes, event)
+		return nil
+	})
+	serve := func(target, body string) int {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	body := `[
+		{"userid":"u01","status":"1","os":"iOS","time":1570000000200,"clientIp":"10.0.0.1:5000"},
+		{"userid":"u01","status":"0","os":"iOS","time":1570000000100,"clientIp":"10.0.0.1:5000"},
+		{"userid":"u02","status":0,"os":"Android","time":"1570000000100"}
+	]`
+	if code := serve(signedWebhookURL("key", "secret", "n1", time.Now()), body); code != http.StatusOK {
+		t.Fatalf("expect 200, got %d", code)
+	}
+	if len(changes) != 3 || changes[2].Status != PresenceOffline || changes[2].ClientIP != "10.0.0.1:5000" {
+		t.Errorf("unexpected changes %+v", changes)
+	}
+	if status, _ := h.Table().OnlineStatus("u01"); status != 0 {
+		t.Error("u01 should be offline")
+	}
+	if status, _ := h.Table().OnlineStatus("u02"); status != 1 {
+		t.Error("u02 should be online")
+	}
+
+	// 重新投递的旧事件不会覆盖状态
+	if code := serve(signedWebhookURL("key", "secret", "n2", time.Now()), `{"userid":"u01","status":"0","os":"iOS","time":1570000000100}`); code != http.StatusOK {
+		t.Fatalf("expect 200, got %d", code)
+	}
+	if len(changes) != 3 {
+		t.Errorf("stale event should not trigger OnChange, got %+v", changes)
+	}
+	if code := serve("/presence", body); code != http.StatusUnauthorized {
+		t.Errorf("expect 401 without signature, got %d", code)
+	}
+	if code := serve(signedWebhookURL("key", "secret", "n3", time.Now()), `[{"status":"0"}]`); code != http.StatusBadRequest {
+		t.Errorf("expect 400 without userid, got %d", code)
+	}
+}
@@ -2,13 +2,8 @@ package sdk
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"net/http"
 	"strings"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // PlatForm 广播类型
@@ -185,18 +180,15 @@ func (rc *RongCloud) PushCustomObj(data PushCustomData) (PushCustomObj, error) {
 	if err != nil {
 		return result, err
 	}
-	req := httplib.Post(rc.rongCloudURI + "/push/custom.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PushCustomObj", http.MethodPost, "/push/custom.json")
 	rc.fillHeader(req)
 	req.Body(body)
 	req.Header("Content-Type", "application/json")
 	code, err := rc.do(req)
 	if err != nil {
-		fmt.Println("do err", err)
 		return result, err
 	}
 	if err := json.Unmarshal(code, &result); err != nil {
-		fmt.Println("unmarshal err", err)
 		return result, err
 	}
 	return result, err
@@ -218,10 +210,8 @@ func (rc *RongCloud) PushCustomResObj(p []byte) (PushCustomObj, error) {
 		err    error
 		result = PushCustomObj{}
 	)
-	url := rc.rongCloudURI + "/push/custom.json"
-	fmt.Println(url)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := "/push/custom.json"
+	req := rc.newRequest("PushCustomResObj", http.MethodPost, path)
 	rc.fillHeader(req)
 	req.Body(p)
 	req.Header("Content-Type", "application/json")
@@ -248,8 +238,7 @@ func (rc *RongCloud) PushCustomResObj(p []byte) (PushCustomObj, error) {
 //*//
 func (rc *RongCloud) PushCustom(p []byte) ([]byte, error) {
 	var err error
-	req := httplib.Post(rc.rongCloudURI + "/push/custom.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PushCustom", http.MethodPost, "/push/custom.json")
 	rc.fillHeader(req)
 	req.Body(p)
 	req.Header("Content-Type", "application/json")
@@ -262,14 +251,32 @@ func (rc *RongCloud) PushCustom(p []byte) ([]byte, error) {
 
 // PushUser 向应用中指定用户发送不落地通知，不落地通知无论用户是否正在使用 App，都会向该用户发送通知，通知只会展示在通知栏，通知中不携带消息内容，登录 App 后不会在聊天页面看到该内容，不会存储到本地数据库。
 func (rc *RongCloud) PushUser(notification *PushNotification, users ...string) error {
+	_, err := rc.PushUserResObj(notification, users...)
+	return err
+}
+
+// PushUserResObj :同 PushUser，返回每一批的调用结果，未拆分时只有一批
+func (rc *RongCloud) PushUserResObj(notification *PushNotification, users ...string) ([]BatchResult, error) {
 	if notification == nil {
-		return errors.New("Invalid notification")
+		return nil, RCErrorNew(1002, "Invalid notification")
+	}
+
+	if rc.shouldFanOut(len(users), PUSH_USER_MAX_USERS) {
+		return rc.fanOut("PushUser", users, PUSH_USER_MAX_USERS, func(batch []string) error {
+			// 各批次并发调用，pushUser 会修改 notification.Android，每批使用一份拷贝
+			n := *notification
+			return rc.pushUser(&n, batch)
+		})
 	}
 
 	if userLens := len(users); userLens > 100 || userLens <= 0 {
-		return errors.New("Invalid users")
+		return nil, RCErrorNew(1002, "Invalid users")
 	}
+	err := rc.pushUser(notification, users)
+	return singleBatch(users, err), err
+}
 
+func (rc *RongCloud) pushUser(notification *PushNotification, users []string) error {
 	if notification.Android != nil {
 		android := make(map[string]interface{})
 		for key, val := range notification.Android {
@@ -281,8 +288,7 @@ func (rc *RongCloud) PushUser(notification *PushNotification, users ...string) e
 
 	var err error
 
-	req := httplib.Post(rc.rongCloudURI + "/push/user." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PushUser", http.MethodPost, "/push/user."+ReqType)
 	rc.fillHeader(req)
 	req, err = req.JSONBody(map[string]interface{}{
 		"userIds":      users,
@@ -304,11 +310,11 @@ func (rc *RongCloud) PushUser(notification *PushNotification, users ...string) e
 
 	code, ok := data["code"]
 	if !ok {
-		return errors.New("Failed to request")
+		return &Error{APIVersion: 1, Message: "Failed to request"}
 	}
 
 	if int(code.(float64)) != http.StatusOK {
-		return fmt.Errorf("Response error. code: %d", int(code.(float64)))
+		return RCErrorNew(int(code.(float64)), "Response error")
 	}
 
 	return nil
@@ -322,8 +328,7 @@ func (rc *RongCloud) PushUser(notification *PushNotification, users ...string) e
 *@return PushResult, error
  */
 func (rc *RongCloud) PushSend(sender Sender) (PushResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/push." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("PushSend", http.MethodPost, "/push."+ReqType)
 	rc.fillHeader(req)
 	req, err := req.JSONBody(sender)
 	if err != nil {
@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitMode 超出调用频率时的处理方式
+type RateLimitMode int
+
+const (
+	// RateLimitBlock 等待到允许调用为止，调用方可以通过 WithContext 设置最长等待时间
+	RateLimitBlock RateLimitMode = iota
+	// RateLimitFailFast 立即返回 *RateLimitError
+	RateLimitFailFast
+)
+
+// RateLimit 令牌桶限制：每 Interval 最多调用 Limit 次，令牌按 Interval/Limit 的间隔逐个补充
+type RateLimit struct {
+	Limit    int
+	Interval time.Duration
+}
+
+// RateQuota 一组共享调用额度的接口
+type RateQuota struct {
+	// Name 额度名称，用于错误信息
+	Name string
+	// Operations 共享额度的接口方法名，如 GroupSend
+	Operations []string
+	// Limits 需要同时满足的限制，如每小时 2 次且每天 3 次
+	Limits []RateLimit
+	// CostParam 按该参数的个数计算消耗的次数，为空时每次调用计 1 次。如向 3 个群发送消息计 3 条
+	CostParam string
+}
+
+// DefaultRateQuotas 融云文档中给出的默认调用频率
+func DefaultRateQuotas() []RateQuota {
+	perSecond := func(n int) []RateLimit {
+		return []RateLimit{{Limit: n, Interval: time.Second}}
+	}
+	return []RateQuota{
+		{Name: "group_send", Operations: []string{"GroupSend", "GroupSendMention"}, Limits: perSecond(20), CostParam: "toGroupId"},
+		{Name: "chatroom_send", Operations: []string{"ChatRoomSend"}, Limits: perSecond(20), CostParam: "toChatroomId"},
+		{Name: "chatroom_broadcast", Operations: []string{"ChatRoomBroadcast"}, Limits: perSecond(20)},
+		{
+			// 推送和广播消息合计每小时只能发送 2 次，每天最多发送 3 次
+			Name:       "broadcast_push",
+			Operations: []string{"SystemBroadcast", "PushSend", "PushCustom", "PushCustomObj", "PushCustomResObj"},
+			Limits:     []RateLimit{{Limit: 2, Interval: time.Hour}, {Limit: 3, Interval: 24 * time.Hour}},
+		},
+		{Name: "blacklist_add", Operations: []string{"BlacklistAdd"}, Limits: perSecond(100)},
+		{Name: "blacklist_remove", Operations: []string{"BlacklistRemove"}, Limits: perSecond(100)},
+		{Name: "blacklist_get", Operations: []string{"BlacklistGet"}, Limits: perSecond(100)},
+		{Name: "whitelist_add", Operations: []string{"AddWhiteList"}, Limits: perSecond(100)},
+		{Name: "whitelist_remove", Operations: []string{"RemoveWhiteList"}, Limits: perSecond(100)},
+		{Name: "whitelist_query", Operations: []string{"QueryWhiteList"}, Limits: perSecond(100)},
+		{Name: "ultragroup_history", Operations: []string{"UGHistoryQuery"}, Limits: []RateLimit{{Limit: 100, Interval: time.Minute}}},
+	}
+}
+
+// RateLimitError 超出客户端调用频率限制，errors.Is(err, ErrRateLimited) 为 true
+type RateLimitError struct {
+	Operation  string        // 接口方法名
+	Quota      string        // 额度名称
+	RetryAfter time.Duration // 距离下次允许调用的时间
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rongcloud: %s exceeds rate quota %s, retry after %s", e.Operation, e.Quota, e.RetryAfter)
+}
+
+// Is 支持 errors.Is(err, ErrRateLimited)
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// WithRateLimit 开启客户端调用频率限制，默认不限制
+// quotas 为空时使用 DefaultRateQuotas()，同一接口出现在多个额度中时以后面的为准。
+// 限制按客户端（即 App-Key）计算，不同 App-Key 的客户端互不影响
+func WithRateLimit(mode RateLimitMode, quotas ...RateQuota) rongCloudOption {
+	return func(o *RongCloud) {
+		if len(quotas) == 0 {
+			quotas = DefaultRateQuotas()
+		}
+		o.rateLimiter = newRateLimiter(mode, quotas)
+	}
+}
+
+// tokenBucket 令牌桶，容量为 Limit，每 Interval/Limit 补充一个令牌
+type tokenBucket struct {
+	capacity float64
+	interval time.Duration // 补充一个令牌的间隔
+	tokens   float64
+	last     time.Time
+}
+
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Limit <= 0 {
+		limit.Limit = 1
+	}
+	return &tokenBucket{
+		capacity: float64(limit.Limit),
+		interval: limit.Interval / time.Duration(limit.Limit),
+		tokens:   float64(limit.Limit),
+		last:     now,
+	}
+}
+
+// refill 按经过的时间补充令牌
+func (b *tokenBucket) refill(now time.Time) {
+	if b.interval <= 0 {
+		b.tokens = b.capacity
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(b.interval)
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+}
+
+// wait 距离桶内有 n 个令牌还需要的时间
+func (b *tokenBucket) wait(n float64) time.Duration {
+	if n > b.capacity {
+		n = b.capacity
+	}
+	if b.tokens >= n {
+		return 0
+	}
+	return time.Duration((n - b.tokens) * float64(b.interval))
+}
+
+type quotaState struct {
+	quota   RateQuota
+	buckets []*tokenBucket
+}
+
+// rateLimiter 按接口限制调用频率
+type rateLimiter struct {
+	mu     sync.Mutex
+	mode   RateLimitMode
+	quotas map[string]*quotaState // key 为接口方法名
+	parent *rateLimiter           // 需要同时满足的外层限制，如 SensitiveSync 外层为 WithRateLimit 开启的限制
+}
+
+func newRateLimiter(mode RateLimitMode, quotas []RateQuota) *rateLimiter {
+	now := time.Now()
+	l := &rateLimiter{mode: mode, quotas: map[string]*quotaState{}}
+	for _, q := range quotas {
+		state := &quotaState{quota: q}
+		for _, limit := range q.Limits {
+			state.buckets = append(state.buckets, newTokenBucket(limit, now))
+		}
+		for _, op := range q.Operations {
+			l.quotas[op] = state
+		}
+	}
+	return l
+}
+
+// reserve 尝试消耗 cost 次额度，成功返回 0，否则返回需要等待的时间
+func (l *rateLimiter) reserve(state *quotaState, cost int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	var wait time.Duration
+	for _, b := range state.buckets {
+		b.refill(now)
+		if w := b.wait(float64(cost)); w > wait {
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+	for _, b := range state.buckets {
+		b.tokens -= float64(cost)
+		if b.tokens < 0 {
+			b.tokens = 0
+		}
+	}
+	return 0
+}
+
+// next 距离 operation 下次允许调用的时间
+func (l *rateLimiter) next(operation string) time.Duration {
+	state, ok := l.quotas[operation]
+	if !ok {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	var wait time.Duration
+	for _, b := range state.buckets {
+		b.refill(now)
+		if w := b.wait(1); w > wait {
+			wait = w
+		}
+	}
+	return wait
+}
+
+// waitRateLimit 按限制消耗一次 req 的额度，阻塞模式下等待到允许调用或调用方取消
+// 存在外层限制时需要同时满足，每层按各自的模式处理
+func (rc *RongCloud) waitRateLimit(req *request) error {
+	for l := rc.rateLimiter; l != nil; l = l.parent {
+		if err := rc.waitQuota(l, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitQuota 按 l 中 req 所属的额度消耗一次调用
+func (rc *RongCloud) waitQuota(l *rateLimiter, req *request) error {
+	state, ok := l.quotas[req.operation]
+	if !ok {
+		return nil
+	}
+	cost := 1
+	if state.quota.CostParam != "" && len(req.params[state.quota.CostParam]) > 1 {
+		cost = len(req.params[state.quota.CostParam])
+	}
+	for {
+		wait := l.reserve(state, cost)
+		if wait == 0 {
+			return nil
+		}
+		if l.mode == RateLimitFailFast {
+			return &RateLimitError{Operation: req.operation, Quota: state.quota.Name, RetryAfter: wait}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-rc.Context().Done():
+			timer.Stop()
+			return rc.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RateLimitWait 距离接口下次允许调用的时间，未开启限制或接口不受限制时返回 0
+// operation 为接口方法名，如 GroupSend
+func (rc *RongCloud) RateLimitWait(operation string) time.Duration {
+	var wait time.Duration
+	for l := rc.rateLimiter; l != nil; l = l.parent {
+		if w := l.next(operation); w > wait {
+			wait = w
+		}
+	}
+	return wait
+}
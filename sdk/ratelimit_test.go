@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := newTokenBucket(RateLimit{Limit: 2, Interval: time.Hour}, now)
+	if w := b.wait(2); w != 0 {
+		t.Fatalf("full bucket should not wait, got %s", w)
+	}
+	b.tokens = 0
+	if w := b.wait(1); w != 30*time.Minute {
+		t.Errorf("expect 30m, got %s", w)
+	}
+	b.refill(now.Add(45 * time.Minute))
+	if b.tokens != 1.5 {
+		t.Errorf("expect 1.5 tokens, got %v", b.tokens)
+	}
+	b.refill(now.Add(10 * time.Hour))
+	if b.tokens != 2 {
+		t.Errorf("tokens should not exceed capacity, got %v", b.tokens)
+	}
+}
+
+func TestWithRateLimit_failFast(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer server.Close()
+
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithRateLimit(RateLimitFailFast))
+	msg := TXTMsg{Content: "hello"}
+	// 向 3 个群发送计 3 条，每秒最多 20 条
+	for i := 0; i < 6; i++ {
+		if err := rc.GroupSend("u01", []string{"g1", "g2", "g3"}, nil, "RC:TxtMsg", &msg, "", "", 1, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := rc.GroupSend("u01", []string{"g1", "g2", "g3"}, nil, "RC:TxtMsg", &msg, "", "", 1, 0)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expect RateLimitError, got %v", err)
+	}
+	if rateErr.Operation != "GroupSend" || rateErr.Quota != "group_send" || rateErr.RetryAfter <= 0 {
+		t.Errorf("unexpected error %+v", rateErr)
+	}
+	if w := rc.RateLimitWait("GroupSend"); w != 0 {
+		t.Errorf("two messages left, expect no wait for a single message, got %s", w)
+	}
+	if w := rc.RateLimitWait("UserRegister"); w != 0 {
+		t.Errorf("unlimited operation should not wait, got %s", w)
+	}
+	if n := atomic.LoadInt32(&count); n != 6 {
+		t.Errorf("expect 6 requests, got %d", n)
+	}
+}
+
+func TestWithRateLimit_block(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer server.Close()
+
+	quota := RateQuota{
+		Name:       "blacklist_add",
+		Operations: []string{"BlacklistAdd"},
+		Limits:     []RateLimit{{Limit: 1, Interval: 50 * time.Millisecond}},
+	}
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithRateLimit(RateLimitBlock, quota))
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := rc.BlacklistAdd("u01", []string{"u02"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expect blocking at least 100ms, got %s", elapsed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := rc.WithContext(ctx).BlacklistAdd("u01", []string{"u02"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expect context.DeadlineExceeded, got %v", err)
+	}
+}
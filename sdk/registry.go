@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"sort"
+	"sync"
+)
+
+// defaultRegistry 包级别的默认 Registry，Register、Lookup、Unregister 使用
+var defaultRegistry = NewRegistry()
+
+// Registry 按 App-Key 管理多个 RongCloud 对象，用于同一进程中使用多个应用的场景
+// 每个 RongCloud 对象使用各自的 Api 地址、域名切换状态和 http 连接
+type Registry struct {
+	mu      sync.RWMutex
+	clients map[string]*RongCloud
+}
+
+// NewRegistry 创建 Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		clients: make(map[string]*RongCloud),
+	}
+}
+
+// Register 注册 RongCloud 对象，同一个 App-Key 已注册时返回错误
+func (r *Registry) Register(rc *RongCloud) error {
+	if rc == nil || rc.appKey == "" {
+		return RCErrorNew(1002, "Paramer 'appKey' is required")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.clients[rc.appKey]; ok {
+		return RCErrorNew(1002, "App-Key '"+rc.appKey+"' already registered")
+	}
+	r.clients[rc.appKey] = rc
+	return nil
+}
+
+// Lookup 按 App-Key 获取 RongCloud 对象
+func (r *Registry) Lookup(appKey string) (*RongCloud, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	rc, ok := r.clients[appKey]
+	return rc, ok
+}
+
+// Unregister 移除 App-Key 对应的 RongCloud 对象
+func (r *Registry) Unregister(appKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, appKey)
+}
+
+// AppKeys 获取已注册的 App-Key，按字典序排列
+func (r *Registry) AppKeys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	appKeys := make([]string, 0, len(r.clients))
+	for appKey := range r.clients {
+		appKeys = append(appKeys, appKey)
+	}
+	sort.Strings(appKeys)
+	return appKeys
+}
+
+// Register 注册 RongCloud 对象到默认 Registry
+func Register(rc *RongCloud) error {
+	return defaultRegistry.Register(rc)
+}
+
+// Lookup 从默认 Registry 按 App-Key 获取 RongCloud 对象
+func Lookup(appKey string) (*RongCloud, bool) {
+	return defaultRegistry.Lookup(appKey)
+}
+
+// Unregister 从默认 Registry 移除 App-Key 对应的 RongCloud 对象
+func Unregister(appKey string) {
+	defaultRegistry.Unregister(appKey)
+}
@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	rc1 := NewRongCloud("key1", "secret1", WithRongCloudURI("http://api1.test.com"))
+	rc2 := NewRongCloud("key2", "secret2", WithRongCloudURIs("http://api2.test.com", "http://api3.test.com"))
+
+	if err := r.Register(rc1); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(rc2); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(NewRongCloud("key1", "other")); err == nil {
+		t.Error("expect error when App-Key already registered")
+	}
+	if err := r.Register(NewRongCloud("", "")); err == nil {
+		t.Error("expect error when App-Key is empty")
+	}
+
+	if rc, ok := r.Lookup("key1"); !ok || rc != rc1 {
+		t.Errorf("expect rc1, got %v", rc)
+	}
+	if rc, ok := r.Lookup("key2"); !ok || rc != rc2 {
+		t.Errorf("expect rc2, got %v", rc)
+	}
+	if !reflect.DeepEqual(r.AppKeys(), []string{"key1", "key2"}) {
+		t.Errorf("unexpected app keys: %v", r.AppKeys())
+	}
+
+	// 每个对象的地址池和连接互不影响
+	rc1.ChangeURI()
+	if states := rc2.EndpointStates(); !states[0].Active || !states[0].Healthy {
+		t.Errorf("rc2 should not be affected by rc1, got %+v", states)
+	}
+	if rc1.GetHttpTransport() == rc2.GetHttpTransport() {
+		t.Error("transport should not be shared")
+	}
+
+	r.Unregister("key1")
+	if _, ok := r.Lookup("key1"); ok {
+		t.Error("key1 should be unregistered")
+	}
+}
+
+func TestNewRongCloud_noGlobalState(t *testing.T) {
+	NewRongCloud("key1", "secret1")
+	NewRongCloud("key2", "secret2")
+	if _, ok := Lookup("key1"); ok {
+		t.Error("NewRongCloud should not register client")
+	}
+	if rc := GetRongCloud(); rc != nil {
+		t.Errorf("expect nil, got %v", rc)
+	}
+
+	rc := NewRongCloud("key3", "secret3")
+	if err := Register(rc); err != nil {
+		t.Fatal(err)
+	}
+	defer Unregister("key3")
+	if got := GetRongCloud(); got != rc {
+		t.Errorf("expect the registered client, got %v", got)
+	}
+}
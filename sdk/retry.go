@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"math/rand"
+	"time"
+)
+
+const (
+	// DEFAULT_RETRY_MAX_ATTEMPTS 默认最大尝试次数（含首次请求）
+	DEFAULT_RETRY_MAX_ATTEMPTS = 3
+	// DEFAULT_RETRY_BASE_DELAY 默认首次重试等待时间
+	DEFAULT_RETRY_BASE_DELAY = 100 * time.Millisecond
+	// DEFAULT_RETRY_MAX_DELAY 默认单次重试最长等待时间
+	DEFAULT_RETRY_MAX_DELAY = 2 * time.Second
+	// DEFAULT_RETRY_JITTER 默认等待时间随机抖动比例
+	DEFAULT_RETRY_JITTER = 0.2
+)
+
+// RetryInfo 失败请求的信息，用于判断是否重试
+type RetryInfo struct {
+	Operation  string // 接口方法名，如 PrivateSend
+	Method     string // http 方法
+	Path       string // 接口路径，如 /message/private/publish.json
+	Attempt    int    // 已经尝试的次数，从 1 开始
+	StatusCode int    // http 状态码，网络错误时为 0
+	Code       int    // 业务返回码，无法解析时为 0
+	Err        error  // 本次请求的错误
+	Idempotent bool   // 是否可以安全地重复发送，v2 接口（带 RC-Request-Id）及 GET 请求为 true
+}
+
+// RetryPolicy 请求重试策略，零值表示不重试
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数（含首次请求），小于等于 1 时不重试
+	MaxAttempts int
+	// BaseDelay 首次重试前的等待时间，之后每次翻倍
+	BaseDelay time.Duration
+	// MaxDelay 单次等待时间上限，0 表示不限制
+	MaxDelay time.Duration
+	// Jitter 等待时间的随机抖动比例，取值 0~1
+	Jitter float64
+	// Retryable 判断请求是否可以重试，为 nil 时使用 DefaultRetryable
+	Retryable func(info RetryInfo) bool
+}
+
+// DefaultRetryPolicy 默认重试策略：最多请求 3 次，等待时间从 100ms 开始指数增长，最长 2s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DEFAULT_RETRY_MAX_ATTEMPTS,
+		BaseDelay:   DEFAULT_RETRY_BASE_DELAY,
+		MaxDelay:    DEFAULT_RETRY_MAX_DELAY,
+		Jitter:      DEFAULT_RETRY_JITTER,
+	}
+}
+
+// DefaultRetryable 默认的重试判断规则
+// 连接失败时请求未到达服务端，任何接口都可以重试；
+// 超时、5xx 以及业务码表中标记为可重试的错误只对可以安全重复发送的请求重试，避免消息等重复下发
+func DefaultRetryable(info RetryInfo) bool {
+	if info.StatusCode == 0 && info.Err != nil {
+		if isDialError(info.Err) {
+			return true
+		}
+		return info.Idempotent && isNetError(info.Err)
+	}
+	if !info.Idempotent {
+		return false
+	}
+	if info.StatusCode >= 500 && info.StatusCode < 600 {
+		return true
+	}
+	codeInfo, ok := LookupErrorCode(info.Code)
+	return ok && codeInfo.Retryable
+}
+
+// shouldRetry 判断第 info.Attempt 次请求失败后是否重试
+func (p RetryPolicy) shouldRetry(info RetryInfo) bool {
+	if info.Attempt >= p.MaxAttempts {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(info)
+	}
+	return DefaultRetryable(info)
+}
+
+// backoff 第 attempt 次请求失败后的等待时间
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 && delay > 0 {
+		delta := float64(delay) * p.Jitter
+		delay += time.Duration(delta * (2*rand.Float64() - 1))
+	}
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
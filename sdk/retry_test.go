@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+	expects := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, expect := range expects {
+		if d := p.backoff(i + 1); d != expect {
+			t.Errorf("attempt %d: expect %v, got %v", i+1, expect, d)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.backoff(1); d < 50*time.Millisecond || d > 150*time.Millisecond {
+			t.Fatalf("jitter out of range: %v", d)
+		}
+	}
+}
+
+func TestDefaultRetryable(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	cases := []struct {
+		info   RetryInfo
+		expect bool
+	}{
+		{RetryInfo{Err: dialErr}, true},
+		{RetryInfo{StatusCode: 500, Err: RCErrorNew(1000, "")}, false},
+		{RetryInfo{StatusCode: 500, Err: RCErrorNew(1000, ""), Idempotent: true}, true},
+		{RetryInfo{StatusCode: 200, Code: 1000, Idempotent: true}, true},
+		{RetryInfo{StatusCode: 400, Code: 1002, Idempotent: true}, false},
+	}
+	for i, c := range cases {
+		if got := DefaultRetryable(c.info); got != c.expect {
+			t.Errorf("case %d: expect %v, got %v", i, c.expect, got)
+		}
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		requestIds []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestIds = append(requestIds, r.Header.Get("RC-Request-Id"))
+		n := len(requestIds)
+		mu.Unlock()
+		if n < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":1000,"msg":"internal error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":10000}`))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	rc := NewRongCloud("key", "secret", WithRongCloudURI(server.URL), WithRetryPolicy(policy))
+	err, requestId := rc.UGGroupCreate("u01", "g01", "group")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requestIds) != 3 {
+		t.Fatalf("expect 3 attempts, got %d", len(requestIds))
+	}
+	for _, id := range requestIds {
+		if id != requestId {
+			t.Errorf("expect RC-Request-Id %s, got %s", requestId, id)
+		}
+	}
+}
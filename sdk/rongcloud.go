@@ -39,6 +39,7 @@
 package sdk
 
 import (
+	"context"
 	"crypto/sha1"
 	"fmt"
 	"io"
@@ -46,10 +47,8 @@ import (
 	"net"
 	"net/http"
 	"strconv"
-	"sync"
 	"time"
 
-	"github.com/astaxie/beego/httplib"
 	"github.com/google/uuid"
 )
 
@@ -70,7 +69,7 @@ const (
 	DEFAULT_KEEPALIVE = 30
 	// DEFAULT_MAXIDLECONNSPERHOST http 默认每个域名连接数，100
 	DEFAULT_MAXIDLECONNSPERHOST = 100
-	// 自动切换 api 地址时间间隔，秒
+	// 自动切换 api 地址时间间隔，秒。失败的地址暂停使用的时间
 	DEFAULT_CHANGE_URI_DURATION = 30
 )
 
@@ -82,11 +81,11 @@ var (
 		keepAlive:           DEFAULT_KEEPALIVE,
 		maxIdleConnsPerHost: DEFAULT_MAXIDLECONNSPERHOST,
 		count:               0,
-		changeUriDuration:   DEFAULT_CHANGE_URI_DURATION,
-		lastChageUriTime:    0,
+		endpointMaxFailures: DEFAULT_ENDPOINT_MAX_FAILURES,
+		endpointCooldown:    DEFAULT_CHANGE_URI_DURATION * time.Second,
+		logger:              nopLogger{},
+		metrics:             nopMetrics{},
 	}
-	rc   *RongCloud
-	once sync.Once
 )
 
 // RongCloud appKey appSecret extra
@@ -94,8 +93,10 @@ type RongCloud struct {
 	appKey    string
 	appSecret string
 	*rongCloudExtra
-	uriLock         sync.Mutex
+	endpoints       *endpointPool
 	globalTransport http.RoundTripper
+	httpClient      *http.Client
+	ctx             context.Context
 }
 
 // rongCloudExtra rongCloud扩展增加自定义融云服务器地址,请求超时时间
@@ -106,8 +107,17 @@ type rongCloudExtra struct {
 	keepAlive           time.Duration
 	maxIdleConnsPerHost int
 	count               uint
-	changeUriDuration   int64
-	lastChageUriTime    int64
+	rongCloudURIs       []string
+	endpointMaxFailures int
+	endpointCooldown    time.Duration
+	endpointProbe       EndpointProbe
+	retryPolicy         RetryPolicy
+	interceptors        []Interceptor
+	logger              Logger
+	metrics             Metrics
+	rateLimiter         *rateLimiter
+	sensitiveFilter     *SensitiveFilter
+	fanOutParallels     int
 }
 
 // getSignature 本地生成签名
@@ -125,7 +135,7 @@ func (rc RongCloud) getSignature() (nonce, timestamp, signature string) {
 }
 
 // fillHeader 在 Http Header 增加API签名
-func (rc RongCloud) fillHeader(req *httplib.BeegoHTTPRequest) {
+func (rc RongCloud) fillHeader(req *request) {
 	nonce, timestamp, signature := rc.getSignature()
 	req.Header("App-Key", rc.appKey)
 	req.Header("Nonce", nonce)
@@ -136,7 +146,7 @@ func (rc RongCloud) fillHeader(req *httplib.BeegoHTTPRequest) {
 }
 
 // v2 sdk header
-func (rc RongCloud) fillHeaderV2(req *httplib.BeegoHTTPRequest) string {
+func (rc RongCloud) fillHeaderV2(req *request) string {
 	requestId := uuid.New().String()
 	nonce, timestamp, signature := rc.getSignature()
 	req.Header("RC-App-Key", rc.appKey)
@@ -146,20 +156,21 @@ func (rc RongCloud) fillHeaderV2(req *httplib.BeegoHTTPRequest) string {
 	req.Header("Content-Type", "application/json")
 	req.Header("User-Agent", USERAGENT)
 	req.Header("RC-Request-Id", requestId)
+	req.requestId = requestId
 	return requestId
 }
 
 // fillJSONHeader 在 Http Header Content-Type 设置为josn格式
-func fillJSONHeader(req *httplib.BeegoHTTPRequest) {
+func fillJSONHeader(req *request) {
 	req.Header("Content-Type", "application/json")
 }
 
 // NewRongCloud 创建 RongCloud 对象
+// 每次调用都返回独立的对象，不会修改包级别的状态；需要按 App-Key 查找时请使用 Register 和 Lookup
 func NewRongCloud(appKey, appSecret string, options ...rongCloudOption) *RongCloud {
 	// 默认扩展配置
 	defaultRongCloud := defaultExtra
-	defaultRongCloud.lastChageUriTime = 0
-	rc = &RongCloud{
+	rc := &RongCloud{
 		appKey:         appKey,
 		appSecret:      appSecret,
 		rongCloudExtra: &defaultRongCloud,
@@ -179,14 +190,57 @@ func NewRongCloud(appKey, appSecret string, options ...rongCloudOption) *RongClo
 		}
 	}
 
+	probe := rc.endpointProbe
+	if probe == nil {
+		probe = rc.probeEndpoint
+	}
+	rc.endpoints = newEndpointPool(rc.endpointURIs(), rc.endpointMaxFailures, rc.endpointCooldown, probe, rc.timeout*time.Second)
+	rc.endpoints.logger = rc.logger
+	rc.endpoints.metrics = rc.metrics
+
 	return rc
 }
 
-// GetRongCloud 获取 RongCloud 对象
+// GetRongCloud 获取默认 Registry 中的 RongCloud 对象
+// 只注册了一个 RongCloud 对象时返回该对象，否则返回 nil；NewRongCloud 创建的对象需要先通过 Register 注册
+//
+// Deprecated: NewRongCloud 不再记录创建的对象，请使用 Register 注册后通过 Lookup 按 App-Key 获取
 func GetRongCloud() *RongCloud {
+	appKeys := defaultRegistry.AppKeys()
+	if len(appKeys) != 1 {
+		return nil
+	}
+	rc, _ := defaultRegistry.Lookup(appKeys[0])
 	return rc
 }
 
+// AppKey 获取 App-Key
+func (rc *RongCloud) AppKey() string {
+	return rc.appKey
+}
+
+// WithContext 返回绑定 ctx 的 RongCloud 副本，用于单次或一组请求
+// 副本与原对象共享配置、域名切换状态和 http 连接，ctx 取消时正在进行的请求立即中止；
+// ctx 设置了 deadline 时以 deadline 为准，否则仍使用 rc.timeout 作为请求超时时间
+//
+//	rc.WithContext(ctx).PrivateSend(...)
+func (rc *RongCloud) WithContext(ctx context.Context) *RongCloud {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c := *rc
+	c.ctx = ctx
+	return &c
+}
+
+// Context 获取绑定的 ctx，未绑定时返回 context.Background()
+func (rc *RongCloud) Context() context.Context {
+	if rc.ctx != nil {
+		return rc.ctx
+	}
+	return context.Background()
+}
+
 // 自定义 http 参数
 func (rc *RongCloud) SetHttpTransport(httpTransport http.RoundTripper) {
 	rc.globalTransport = httpTransport
@@ -196,44 +250,37 @@ func (rc *RongCloud) GetHttpTransport() http.RoundTripper {
 	return rc.globalTransport
 }
 
+// endpointURIs 地址池使用的 Api 地址
+// 使用默认地址时自动加入备用地址
+func (rc *RongCloud) endpointURIs() []string {
+	if len(rc.rongCloudURIs) > 0 {
+		return rc.rongCloudURIs
+	}
+	switch rc.rongCloudURI {
+	case RONGCLOUDURI:
+		return []string{RONGCLOUDURI, RONGCLOUDURI2}
+	case RONGCLOUDURI2:
+		return []string{RONGCLOUDURI2, RONGCLOUDURI}
+	}
+	return []string{rc.rongCloudURI}
+}
+
 // changeURI 自动切换 Api 服务器地址
-// 在 api、api2之间自动切换。无法切换其他域名。其他请使用 PrivateURI 设置
+// 暂停使用当前地址，切换到地址池中下一个可用的地址，暂停的地址冷却结束并探测成功后恢复使用
 func (rc *RongCloud) ChangeURI() {
-	nowUnix := time.Now().Unix()
-	// 检查距离上次更换uri的时间间隔
-	rc.uriLock.Lock()
-	if (nowUnix - rc.lastChageUriTime) >= rc.changeUriDuration {
-		switch rc.rongCloudURI {
-		case RONGCLOUDURI:
-			rc.rongCloudURI = RONGCLOUDURI2
-		case RONGCLOUDURI2:
-			rc.rongCloudURI = RONGCLOUDURI
-		default:
-		}
-		rc.lastChageUriTime = nowUnix
-	}
-	rc.uriLock.Unlock()
+	rc.endpoints.skip()
 }
 
 // PrivateURI 私有云设置 Api 地址
+// 多个 Api 地址请使用 WithRongCloudURIs 设置
 func (rc *RongCloud) PrivateURI(uri, sms string) {
 	rc.rongCloudURI = uri
 	rc.rongCloudSMSURI = sms
+	rc.rongCloudURIs = nil
+	rc.endpoints.reset(rc.endpointURIs())
 }
 
 // urlError 判断是否为 url.Error
 func (rc *RongCloud) urlError(err error) {
 	// 方法已废弃
 }
-
-/*
-*
-判断 http status code, 如果大于 500 就切换一次域名
-*/
-func (rc *RongCloud) checkStatusCode(resp *http.Response) {
-	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
-		rc.ChangeURI()
-	}
-
-	return
-}
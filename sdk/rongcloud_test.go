@@ -1,6 +1,8 @@
 package sdk
 
 import (
+    "context"
+    "errors"
     "os"
     "testing"
 )
@@ -21,3 +23,25 @@ func TestGetRongCloud(t *testing.T) {
     rc := GetRongCloud()
     t.Log(rc)
 }
+
+func TestRongCloud_WithContext(t *testing.T) {
+    rc := NewRongCloud(
+        os.Getenv("APP_KEY"),
+        os.Getenv("APP_SECRET"),
+    )
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    _, err := rc.WithContext(ctx).UserRegister("u01", "u01", "http://rongcloud.cn/portrait.jpg")
+    if !errors.Is(err, context.Canceled) {
+        t.Fatalf("expect context.Canceled, got %v", err)
+    }
+    for _, state := range rc.EndpointStates() {
+        if state.TotalFailures != 0 {
+            t.Errorf("uri should not change when context canceled, got %+v", state)
+        }
+    }
+    if rc.Context() != context.Background() {
+        t.Error("origin RongCloud should not bind context")
+    }
+}
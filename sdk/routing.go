@@ -0,0 +1,247 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ChannelType 消息路由中的会话类型
+type ChannelType string
+
+const (
+	ChannelTypePerson          ChannelType = "PERSON"          // ChannelTypePerson 二人会话
+	ChannelTypePersons         ChannelType = "PERSONS"         // ChannelTypePersons 讨论组
+	ChannelTypeGroup           ChannelType = "GROUP"           // ChannelTypeGroup 群组会话
+	ChannelTypeTempGroup       ChannelType = "TEMPGROUP"       // ChannelTypeTempGroup 聊天室
+	ChannelTypeCustomerService ChannelType = "CUSTOMERSERVICE" // ChannelTypeCustomerService 客服会话
+	ChannelTypeNotify          ChannelType = "NOTIFY"          // ChannelTypeNotify 系统通知
+	ChannelTypeMC              ChannelType = "MC"              // ChannelTypeMC 应用公众服务
+	ChannelTypeMP              ChannelType = "MP"              // ChannelTypeMP 公众服务
+	ChannelTypeUltraGroup      ChannelType = "ULTRAGROUP"      // ChannelTypeUltraGroup 超级群
+)
+
+// RoutedMessage 全量消息路由推送的一条消息
+type RoutedMessage struct {
+	FromUserID     string      `json:"fromUserId"`
+	ToUserID       string      `json:"toUserId"`
+	ObjectName     string      `json:"objectName"`
+	Content        string      `json:"content"` // 消息内容原文，JSON 字符串
+	ChannelType    ChannelType `json:"channelType"`
+	MsgTimestamp   int64       `json:"msgTimestamp"` // 服务端收到消息的时间，毫秒
+	MsgUID         string      `json:"msgUID"`
+	OriginalMsgUID string      `json:"originalMsgUID"`
+	SensitiveType  int         `json:"sensitiveType"` // 0 未命中敏感词，1 含屏蔽敏感词，2 含替换敏感词
+	Source         string      `json:"source"`
+	BusChannel     string      `json:"busChannel"`
+	GroupUserIDs   []string    `json:"groupUserIds"` // 群定向消息的接收人
+
+	// Message 按 objectName 解析后的消息，如 *TXTMsg、*ImgMsg，自定义消息通过 RegisterMessage 注册后也会解析。未注册或解析失败时为 nil，可以自行解析 Content
+	Message rcMsg `json:"-"`
+}
+
+// routedMessageJSON JSON 推送中 msgTimestamp、sensitiveType 可能是字符串，content 可能是对象
+type routedMessageJSON struct {
+	FromUserID     string          `json:"fromUserId"`
+	ToUserID       string          `json:"toUserId"`
+	ObjectName     string          `json:"objectName"`
+	Content        json.RawMessage `json:"content"`
+	ChannelType    ChannelType     `json:"channelType"`
+	MsgTimestamp   json.Number     `json:"msgTimestamp"`
+	MsgUID         string          `json:"msgUID"`
+	OriginalMsgUID string          `json:"originalMsgUID"`
+	SensitiveType  json.Number     `json:"sensitiveType"`
+	Source         string          `json:"source"`
+	BusChannel     string          `json:"busChannel"`
+	GroupUserIDs   []string        `json:"groupUserIds"`
+}
+
+// decodeMessageContent 按 objectName 解析 content，未注册的消息或解析失败时返回 nil
+func decodeMessageContent(objectName, content string) rcMsg {
+	if content == "" {
+		return nil
+	}
+	m, err := defaultMessageRegistry.Decode(objectName, content)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// rawContent content 为 JSON 字符串时取字符串内容，为对象时保留原文
+func rawContent(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+	if raw[0] != '"' {
+		return string(raw), nil
+	}
+	var content string
+	err := json.Unmarshal(raw, &content)
+	return content, err
+}
+
+// ParseRoutedMessages 解析消息路由请求，支持 application/x-www-form-urlencoded 表单，
+// 以及 application/json 格式的单条消息或消息数组（批量推送）
+func ParseRoutedMessages(r *http.Request) ([]*RoutedMessage, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/json" {
+		return parseRoutedJSON(r.Body)
+	}
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	form := r.PostForm
+	msg := &RoutedMessage{
+		FromUserID:     form.Get("fromUserId"),
+		ToUserID:       form.Get("toUserId"),
+		ObjectName:     form.Get("objectName"),
+		Content:        form.Get("content"),
+		ChannelType:    ChannelType(form.Get("channelType")),
+		MsgUID:         form.Get("msgUID"),
+		OriginalMsgUID: form.Get("originalMsgUID"),
+		Source:         form.Get("source"),
+		BusChannel:     form.Get("busChannel"),
+		GroupUserIDs:   form["groupUserIds"],
+	}
+	var err error
+	if v := form.Get("msgTimestamp"); v != "" {
+		if msg.MsgTimestamp, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid msgTimestamp %s", v)
+		}
+	}
+	if v := form.Get("sensitiveType"); v != "" {
+		if msg.SensitiveType, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid sensitiveType %s", v)
+		}
+	}
+	msg.Message = decodeMessageContent(msg.ObjectName, msg.Content)
+	return []*RoutedMessage{msg}, nil
+}
+
+// parseRoutedJSON 解析 JSON 格式的单条消息或消息数组
+func parseRoutedJSON(body io.Reader) ([]*RoutedMessage, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	var raws []routedMessageJSON
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &raws)
+	} else {
+		raws = make([]routedMessageJSON, 1)
+		err = json.Unmarshal(data, &raws[0])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*RoutedMessage, 0, len(raws))
+	for _, raw := range raws {
+		msg := &RoutedMessage{
+			FromUserID:     raw.FromUserID,
+			ToUserID:       raw.ToUserID,
+			ObjectName:     raw.ObjectName,
+			ChannelType:    raw.ChannelType,
+			MsgUID:         raw.MsgUID,
+			OriginalMsgUID: raw.OriginalMsgUID,
+			Source:         raw.Source,
+			BusChannel:     raw.BusChannel,
+			GroupUserIDs:   raw.GroupUserIDs,
+		}
+		if msg.Content, err = rawContent(raw.Content); err != nil {
+			return nil, err
+		}
+		if raw.MsgTimestamp != "" {
+			if msg.MsgTimestamp, err = raw.MsgTimestamp.Int64(); err != nil {
+				return nil, fmt.Errorf("invalid msgTimestamp %s", raw.MsgTimestamp)
+			}
+		}
+		if raw.SensitiveType != "" {
+			n, err := raw.SensitiveType.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid sensitiveType %s", raw.SensitiveType)
+			}
+			msg.SensitiveType = int(n)
+		}
+		msg.Message = decodeMessageContent(msg.ObjectName, msg.Content)
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// RouteHandler 处理一条路由消息，返回错误时响应 500
+type RouteHandler func(ctx context.Context, msg *RoutedMessage) error
+
+// MessageRouter 全量消息路由的 http.Handler，按会话类型分发到注册的 RouteHandler
+type MessageRouter struct {
+	mu       sync.RWMutex
+	verifier *WebhookVerifier
+	handlers map[ChannelType]RouteHandler
+	fallback RouteHandler
+}
+
+// NewMessageRouter 创建消息路由处理器，verifier 不为 nil 时先校验签名，校验失败响应 401
+func NewMessageRouter(verifier *WebhookVerifier) *MessageRouter {
+	return &MessageRouter{
+		verifier: verifier,
+		handlers: map[ChannelType]RouteHandler{},
+	}
+}
+
+// Handle 注册会话类型的处理函数，同一会话类型重复注册时以后注册的为准
+func (m *MessageRouter) Handle(channelType ChannelType, handler RouteHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[channelType] = handler
+}
+
+// HandleDefault 注册未单独注册的会话类型的处理函数，未设置时忽略这些消息
+func (m *MessageRouter) HandleDefault(handler RouteHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fallback = handler
+}
+
+func (m *MessageRouter) handler(channelType ChannelType) RouteHandler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if h, ok := m.handlers[ChannelType(strings.ToUpper(string(channelType)))]; ok {
+		return h
+	}
+	return m.fallback
+}
+
+// ServeHTTP 解析并按顺序分发请求中的消息，全部处理成功后响应 200
+func (m *MessageRouter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.verifier != nil {
+		if err := m.verifier.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	messages, err := ParseRoutedMessages(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, msg := range messages {
+		h := m.handler(msg.ChannelType)
+		if h == nil {
+			continue
+		}
+		if err := h(r.Context(), msg); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRoutedMessages_form(t *testing.T) {
+	form := url.Values{
+		"fromUserId":   {"u01"},
+		"toUserId":     {"g01"},
+		"objectName":   {"RC:TxtMsg"},
+		"content":      {`{"content":"hello","extra":"x"}`},
+		"channelType":  {"GROUP"},
+		"msgTimestamp": {"1570000000000"},
+		"msgUID":       {"BD3A-0001"},
+		"groupUserIds": {"u02", "u03"},
+	}
+	r := httptest.NewRequest(http.MethodPost, "/route", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	messages, err := ParseRoutedMessages(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("expect 1 message, got %d", len(messages))
+	}
+	msg := messages[0]
+	if msg.FromUserID != "u01" || msg.ChannelType != ChannelTypeGroup || msg.MsgTimestamp != 1570000000000 || len(msg.GroupUserIDs) != 2 {
+		t.Errorf("unexpected message %+v", msg)
+	}
+	txt, ok := msg.Message.(*TXTMsg)
+	if !ok || txt.Content != "hello" || txt.Extra != "x" {
+		t.Errorf("unexpected content %#v", msg.Message)
+	}
+}
+
+func TestParseRoutedMessages_json(t *testing.T) {
+	body := `[
+		{"fromUserId":"u01","toUserId":"u02","objectName":"RC:ImgMsg","content":"{\"imageUri\":\"http://example.com/a.png\"}","channelType":"PERSON","msgTimestamp":"1570000000000"},
+		{"fromUserId":"u01","toUserId":"u02","objectName":"RC:LBSMsg","content":{"poi":"here","latitude":1.5},"channelType":"PERSON","msgTimestamp":1570000000001},
+		{"fromUserId":"u01","toUserId":"u02","objectName":"App:Custom","content":"{\"a\":1}","channelType":"PERSON"}
+	]`
+	r := httptest.NewRequest(http.MethodPost, "/route", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	messages, err := ParseRoutedMessages(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expect 3 messages, got %d", len(messages))
+	}
+	if img, ok := messages[0].Message.(*ImgMsg); !ok || img.ImageURI != "http://example.com/a.png" || messages[0].MsgTimestamp != 1570000000000 {
+		t.Errorf("unexpected message %+v", messages[0])
+	}
+	if lbs, ok := messages[1].Message.(*LBSMsg); !ok || lbs.POI != "here" || lbs.Latitude != 1.5 || messages[1].MsgTimestamp != 1570000000001 {
+		t.Errorf("unexpected message %+v", messages[1])
+	}
+	if messages[2].Message != nil || messages[2].Content != `{"a":1}` {
+		t.Errorf("unexpected message %+v", messages[2])
+	}
+}
+
+func TestMessageRouter(t *testing.T) {
+	router := NewMessageRouter(NewWebhookVerifier("key", "secret"))
+	var persons, others []string
+	router.Handle(ChannelTypePerson, func(ctx context.Context, msg *RoutedMessage) error {
+		persons = append(persons, msg.MsgUID)
+		return nil
+	})
+	router.HandleDefault(func(ctx context.Context, msg *RoutedMessage) error {
+		if msg.MsgUID == "fail" {
+			return errors.New("failed")
+		}
+		others = append(others, msg.MsgUID)
+		return nil
+	})
+
+	serve := func(target, body string) int {
+		r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+	body := `[{"channelType":"PERSON","msgUID":"m1"},{"channelType":"GROUP","msgUID":"m2"},{"channelType":"PERSON","msgUID":"m3"}]`
+	if code := serve(signedWebhookURL("key", "secret", "n1", time.Now()), body); code != http.StatusOK {
+		t.Fatalf("expect 200, got %d", code)
+	}
+	if strings.Join(persons, ",") != "m1,m3" || strings.Join(others, ",") != "m2" {
+		t.Errorf("unexpected dispatch %v %v", persons, others)
+	}
+
+	if code := serve("/route", body); code != http.StatusUnauthorized {
+		t.Errorf("expect 401 without signature, got %d", code)
+	}
+	if code := serve(signedWebhookURL("key", "secret", "n2", time.Now()), `{"channelType":"GROUP","msgUID":"fail"}`); code != http.StatusInternalServerError {
+		t.Errorf("expect 500 on handler error, got %d", code)
+	}
+	if code := serve(signedWebhookURL("key", "secret", "n3", time.Now()), `{`); code != http.StatusBadRequest {
+		t.Errorf("expect 400 on malformed body, got %d", code)
+	}
+}
@@ -0,0 +1,559 @@
+package sdktest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// handler v1 接口处理方法，调用时已持有 s.mu
+type handler func(s *Server, c *call)
+
+// handlers v1 表单接口
+var handlers = map[string]handler{
+	// 用户
+	"/user/getToken.json":         (*Server).userRegister,
+	"/user/refresh.json":          (*Server).userUpdate,
+	"/user/checkOnline.json":      (*Server).userCheckOnline,
+	"/user/block.json":            (*Server).userBlock,
+	"/user/unblock.json":          (*Server).userUnblock,
+	"/user/block/query.json":      (*Server).userBlockQuery,
+	"/user/blacklist/add.json":    (*Server).blacklistAdd,
+	"/user/blacklist/remove.json": (*Server).blacklistRemove,
+	"/user/blacklist/query.json":  (*Server).blacklistQuery,
+
+	// 群组
+	"/group/create.json":     (*Server).groupJoin,
+	"/group/join.json":       (*Server).groupJoin,
+	"/group/quit.json":       (*Server).groupQuit,
+	"/group/dismiss.json":    (*Server).groupDismiss,
+	"/group/refresh.json":    (*Server).groupRefresh,
+	"/group/user/query.json": (*Server).groupUserQuery,
+
+	// 聊天室
+	"/chatroom/create.json":      (*Server).chatroomCreate,
+	"/chatroom/create_new.json":  (*Server).chatroomCreateNew,
+	"/chatroom/destroy.json":     (*Server).chatroomDestroy,
+	"/chatroom/query.json":       (*Server).chatroomQuery,
+	"/chatroom/get.json":         (*Server).chatroomGet,
+	"/chatroom/user/query.json":  (*Server).chatroomUserQuery,
+	"/chatroom/user/exist.json":  (*Server).chatroomUserExist,
+	"/chatroom/users/exist.json": (*Server).chatroomUsersExist,
+
+	// 超级群
+	"/ultragroup/create.json":       (*Server).ultragroupCreate,
+	"/ultragroup/dis.json":          (*Server).ultragroupDismiss,
+	"/ultragroup/join.json":         (*Server).ultragroupJoin,
+	"/ultragroup/quit.json":         (*Server).ultragroupQuit,
+	"/ultragroup/refresh.json":      (*Server).ultragroupRefresh,
+	"/ultragroup/member/exist.json": (*Server).ultragroupMemberExist,
+
+	// 消息
+	"/message/private/publish.json":    messagePublish("private", "toUserId", "userId", 1000),
+	"/message/group/publish.json":      messagePublish("group", "toGroupId", "groupId", 3),
+	"/message/chatroom/publish.json":   messagePublish("chatroom", "toChatroomId", "chatroomId", 0),
+	"/message/system/publish.json":     messagePublish("system", "toUserId", "userId", 100),
+	"/message/ultragroup/publish.json": (*Server).ultragroupPublish,
+	"/push/user.json":                  (*Server).pushUser,
+
+	// 敏感词
+	"/sensitiveword/add.json":          (*Server).sensitiveAdd,
+	"/sensitiveword/list.json":         (*Server).sensitiveList,
+	"/sensitiveword/batch/delete.json": (*Server).sensitiveRemove,
+}
+
+func (s *Server) userRegister(c *call) {
+	if !c.required("userId", "name") {
+		return
+	}
+	u := s.user(c.param("userId"))
+	u.Name = c.param("name")
+	u.PortraitURI = c.param("portraitUri")
+	u.Token = fmt.Sprintf("%s@%s;%s", u.ID, s.AppKey, strconv.FormatInt(time.Now().UnixNano(), 36))
+	c.ok(map[string]interface{}{"userId": u.ID, "token": u.Token})
+}
+
+func (s *Server) userUpdate(c *call) {
+	if !c.required("userId") {
+		return
+	}
+	u := s.user(c.param("userId"))
+	if name := c.param("name"); name != "" {
+		u.Name = name
+	}
+	if portrait := c.param("portraitUri"); portrait != "" {
+		u.PortraitURI = portrait
+	}
+	c.ok(nil)
+}
+
+func (s *Server) userCheckOnline(c *call) {
+	if !c.required("userId") {
+		return
+	}
+	status := "0"
+	if u, ok := s.users[c.param("userId")]; ok && u.Online {
+		status = "1"
+	}
+	c.ok(map[string]interface{}{"status": status})
+}
+
+func (s *Server) userBlock(c *call) {
+	if !c.required("userId", "minute") {
+		return
+	}
+	minute, err := strconv.Atoi(c.param("minute"))
+	if err != nil || minute <= 0 || minute > 43200 {
+		c.fail(http.StatusBadRequest, CodeParam, "minute is invalid")
+		return
+	}
+	s.user(c.param("userId")).BlockUntil = time.Now().Add(time.Duration(minute) * time.Minute)
+	c.ok(nil)
+}
+
+func (s *Server) userUnblock(c *call) {
+	if !c.required("userId") {
+		return
+	}
+	if u, ok := s.users[c.param("userId")]; ok {
+		u.BlockUntil = time.Time{}
+	}
+	c.ok(nil)
+}
+
+func (s *Server) userBlockQuery(c *call) {
+	now := time.Now()
+	users := []map[string]interface{}{}
+	for _, id := range s.userIds() {
+		u := s.users[id]
+		if u.BlockUntil.After(now) {
+			users = append(users, map[string]interface{}{
+				"userId":       u.ID,
+				"blockEndTime": u.BlockUntil.Format("2006-01-02 15:04:05"),
+			})
+		}
+	}
+	c.ok(map[string]interface{}{"users": users})
+}
+
+func (s *Server) blacklistAdd(c *call) {
+	if !c.required("userId", "blackUserId") || !c.max("blackUserId", 20) {
+		return
+	}
+	id := c.param("userId")
+	s.blacklists[id] = appendUnique(s.blacklists[id], c.params("blackUserId")...)
+	c.ok(nil)
+}
+
+func (s *Server) blacklistRemove(c *call) {
+	if !c.required("userId", "blackUserId") {
+		return
+	}
+	id := c.param("userId")
+	s.blacklists[id] = remove(s.blacklists[id], c.params("blackUserId")...)
+	c.ok(nil)
+}
+
+func (s *Server) blacklistQuery(c *call) {
+	if !c.required("userId") {
+		return
+	}
+	users := append([]string{}, s.blacklists[c.param("userId")]...)
+	c.ok(map[string]interface{}{"users": users})
+}
+
+// groupJoin 创建群组和加入群组，群组不存在时创建
+func (s *Server) groupJoin(c *call) {
+	if !c.required("groupId", "userId") || !c.max("userId", 1000) {
+		return
+	}
+	id := c.param("groupId")
+	g, ok := s.groups[id]
+	if !ok {
+		g = &Group{ID: id}
+		s.groups[id] = g
+	}
+	if name := c.param("groupName"); name != "" {
+		g.Name = name
+	}
+	g.Members = appendUnique(g.Members, c.params("userId")...)
+	c.ok(nil)
+}
+
+func (s *Server) groupQuit(c *call) {
+	if !c.required("groupId", "userId") || !c.max("userId", 1000) {
+		return
+	}
+	if g, ok := s.groups[c.param("groupId")]; ok {
+		g.Members = remove(g.Members, c.params("userId")...)
+	}
+	c.ok(nil)
+}
+
+func (s *Server) groupDismiss(c *call) {
+	if !c.required("groupId", "userId") {
+		return
+	}
+	delete(s.groups, c.param("groupId"))
+	c.ok(nil)
+}
+
+func (s *Server) groupRefresh(c *call) {
+	if !c.required("groupId", "groupName") {
+		return
+	}
+	g, ok := s.groups[c.param("groupId")]
+	if !ok {
+		c.fail(http.StatusBadRequest, CodeParam, "group not exist")
+		return
+	}
+	g.Name = c.param("groupName")
+	c.ok(nil)
+}
+
+func (s *Server) groupUserQuery(c *call) {
+	if !c.required("groupId") {
+		return
+	}
+	users := []map[string]interface{}{}
+	if g, ok := s.groups[c.param("groupId")]; ok {
+		for _, id := range g.Members {
+			users = append(users, map[string]interface{}{"id": id})
+		}
+	}
+	c.ok(map[string]interface{}{"users": users})
+}
+
+func (s *Server) chatroomCreate(c *call) {
+	created := false
+	for key := range c.req.Form {
+		if !strings.HasPrefix(key, "chatroom[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		id := key[len("chatroom[") : len(key)-1]
+		if _, ok := s.chatrooms[id]; !ok {
+			s.chatrooms[id] = &Chatroom{ID: id, CreatedAt: time.Now()}
+		}
+		s.chatrooms[id].Name = c.param(key)
+		created = true
+	}
+	if !created {
+		c.fail(http.StatusBadRequest, CodeParam, "chatroom is required")
+		return
+	}
+	c.ok(nil)
+}
+
+func (s *Server) chatroomCreateNew(c *call) {
+	if !c.required("chatroomId") {
+		return
+	}
+	id := c.param("chatroomId")
+	if _, ok := s.chatrooms[id]; !ok {
+		s.chatrooms[id] = &Chatroom{ID: id, CreatedAt: time.Now()}
+	}
+	c.ok(nil)
+}
+
+func (s *Server) chatroomDestroy(c *call) {
+	if !c.required("chatroomId") {
+		return
+	}
+	for _, id := range c.params("chatroomId") {
+		delete(s.chatrooms, id)
+	}
+	c.ok(nil)
+}
+
+func (s *Server) chatroomQuery(c *call) {
+	rooms := []map[string]interface{}{}
+	for _, id := range c.params("chatroomId") {
+		if room, ok := s.chatrooms[id]; ok {
+			rooms = append(rooms, map[string]interface{}{
+				"chrmId": room.ID,
+				"name":   room.Name,
+				"time":   room.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+	}
+	c.ok(map[string]interface{}{"chatRooms": rooms})
+}
+
+func (s *Server) chatroomGet(c *call) {
+	if !c.required("chatroomId") {
+		return
+	}
+	room, ok := s.chatrooms[c.param("chatroomId")]
+	if !ok {
+		c.fail(http.StatusBadRequest, CodeParam, "chatroom not exist")
+		return
+	}
+	c.ok(map[string]interface{}{
+		"chatroomId":  room.ID,
+		"createTime":  room.CreatedAt.UnixNano() / int64(time.Millisecond),
+		"memberCount": len(room.Members),
+	})
+}
+
+func (s *Server) chatroomUserQuery(c *call) {
+	if !c.required("chatroomId") {
+		return
+	}
+	var members []string
+	if room, ok := s.chatrooms[c.param("chatroomId")]; ok {
+		members = append(members, room.Members...)
+	}
+	if c.param("order") == "2" {
+		for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+			members[i], members[j] = members[j], members[i]
+		}
+	}
+	total := len(members)
+	if count, err := strconv.Atoi(c.param("count")); err == nil && count >= 0 && count < len(members) {
+		members = members[:count]
+	}
+	users := []map[string]interface{}{}
+	for _, id := range members {
+		users = append(users, map[string]interface{}{"id": id})
+	}
+	c.ok(map[string]interface{}{"total": total, "users": users})
+}
+
+func (s *Server) chatroomUserExist(c *call) {
+	if !c.required("chatroomId", "userId") {
+		return
+	}
+	room, ok := s.chatrooms[c.param("chatroomId")]
+	c.ok(map[string]interface{}{"isInChrm": ok && contains(room.Members, c.param("userId"))})
+}
+
+func (s *Server) chatroomUsersExist(c *call) {
+	if !c.required("chatroomId", "userId") {
+		return
+	}
+	room, ok := s.chatrooms[c.param("chatroomId")]
+	result := []map[string]interface{}{}
+	for _, id := range c.params("userId") {
+		in := 0
+		if ok && contains(room.Members, id) {
+			in = 1
+		}
+		result = append(result, map[string]interface{}{"userId": id, "isInChrm": in})
+	}
+	c.ok(map[string]interface{}{"result": result})
+}
+
+func (s *Server) ultragroupCreate(c *call) {
+	if !c.required("userId", "groupId", "groupName") {
+		return
+	}
+	s.createUltragroup(c.param("groupId"), c.param("groupName"), c.param("userId"))
+	c.ok(nil)
+}
+
+func (s *Server) ultragroupDismiss(c *call) {
+	if !c.required("groupId") {
+		return
+	}
+	if !s.dismissUltragroup(c.param("groupId")) {
+		c.fail(http.StatusBadRequest, CodeParam, "group not exist")
+		return
+	}
+	c.ok(nil)
+}
+
+func (s *Server) ultragroupJoin(c *call) {
+	if !c.required("userId", "groupId") {
+		return
+	}
+	g, ok := s.ultragroups[c.param("groupId")]
+	if !ok {
+		c.fail(http.StatusBadRequest, CodeParam, "group not exist")
+		return
+	}
+	g.Members = appendUnique(g.Members, c.param("userId"))
+	c.ok(nil)
+}
+
+func (s *Server) ultragroupQuit(c *call) {
+	if !c.required("userId", "groupId") {
+		return
+	}
+	if g, ok := s.ultragroups[c.param("groupId")]; ok {
+		g.Members = remove(g.Members, c.param("userId"))
+	}
+	c.ok(nil)
+}
+
+func (s *Server) ultragroupRefresh(c *call) {
+	if !c.required("groupId", "groupName") {
+		return
+	}
+	g, ok := s.ultragroups[c.param("groupId")]
+	if !ok {
+		c.fail(http.StatusBadRequest, CodeParam, "group not exist")
+		return
+	}
+	g.Name = c.param("groupName")
+	c.ok(nil)
+}
+
+func (s *Server) ultragroupMemberExist(c *call) {
+	if !c.required("groupId", "userId") {
+		return
+	}
+	g, ok := s.ultragroups[c.param("groupId")]
+	c.ok(map[string]interface{}{"status": ok && contains(g.Members, c.param("userId"))})
+}
+
+// messagePublish 发送消息，targetParam 为接收方参数名
+func messagePublish(typ, targetParam, targetKey string, max int) handler {
+	return func(s *Server, c *call) {
+		if !c.required("fromUserId", targetParam, "objectName", "content") || !c.max(targetParam, max) {
+			return
+		}
+		to := append([]string(nil), c.params(targetParam)...)
+		msg := s.publish(typ, c.param("fromUserId"), to, c.param("objectName"), c.param("content"))
+		c.ok(map[string]interface{}{"messageUIDs": messageUIDs(msg, targetKey)})
+	}
+}
+
+// publish 记录消息，为每个目标分配消息 ID
+func (s *Server) publish(typ, from string, to []string, objectName, content string) Message {
+	msg := Message{Type: typ, From: from, To: to, ObjectName: objectName, Content: content}
+	for range to {
+		msg.MessageUIDs = append(msg.MessageUIDs, s.nextID("MSG-"))
+	}
+	s.messages = append(s.messages, msg)
+	return msg
+}
+
+// messageUIDs 发送消息接口返回的消息 ID 列表
+func messageUIDs(msg Message, targetKey string) []map[string]string {
+	uids := make([]map[string]string, len(msg.To))
+	for i, id := range msg.To {
+		uids[i] = map[string]string{targetKey: id, "messageUID": msg.MessageUIDs[i]}
+	}
+	return uids
+}
+
+// ultragroupPublish 发送超级群消息，请求体为 json
+func (s *Server) ultragroupPublish(c *call) {
+	var body struct {
+		FromUserId string   `json:"fromUserId"`
+		ToGroupIds []string `json:"toGroupIds"`
+		ObjectName string   `json:"objectName"`
+		Content    string   `json:"content"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	if body.FromUserId == "" || len(body.ToGroupIds) == 0 || body.ObjectName == "" || body.Content == "" {
+		c.fail(http.StatusBadRequest, CodeParam, "fromUserId, toGroupIds, objectName and content are required")
+		return
+	}
+	msg := s.publish("ultragroup", body.FromUserId, body.ToGroupIds, body.ObjectName, body.Content)
+	c.ok(map[string]interface{}{"messageUIDs": messageUIDs(msg, "groupId")})
+}
+
+// pushUser 发送不落地通知，请求体为 json，每次最多 100 个用户
+func (s *Server) pushUser(c *call) {
+	var body struct {
+		UserIds      []string `json:"userIds"`
+		Notification struct {
+			PushContent string `json:"pushContent"`
+		} `json:"notification"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	if len(body.UserIds) == 0 || len(body.UserIds) > 100 {
+		c.fail(http.StatusBadRequest, CodeParam, "userIds must contain 1 to 100 users")
+		return
+	}
+	s.messages = append(s.messages, Message{
+		Type:    "push",
+		To:      body.UserIds,
+		Content: body.Notification.PushContent,
+	})
+	c.ok(nil)
+}
+
+// createUltragroup 创建超级群，创建者自动加入
+func (s *Server) createUltragroup(id, name, owner string) {
+	g, ok := s.ultragroups[id]
+	if !ok {
+		g = &Group{ID: id}
+		s.ultragroups[id] = g
+	}
+	g.Name = name
+	g.Members = appendUnique(g.Members, owner)
+}
+
+func (s *Server) dismissUltragroup(id string) bool {
+	if _, ok := s.ultragroups[id]; !ok {
+		return false
+	}
+	delete(s.ultragroups, id)
+	return true
+}
+
+// userIds 所有用户 ID，按字典序排列
+func (s *Server) userIds() []string {
+	ids := make([]string, 0, len(s.users))
+	for id := range s.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sensitiveAdd 添加敏感词，没有 replaceWord 时为屏蔽词
+func (s *Server) sensitiveAdd(c *call) {
+	if !c.required("word") {
+		return
+	}
+	word := SensitiveWord{Word: c.param("word"), Type: "1"}
+	if replace := c.param("replaceWord"); replace != "" {
+		word.Type = "0"
+		word.ReplaceWord = replace
+	}
+	s.sensitive[word.Word] = word
+	c.ok(nil)
+}
+
+func (s *Server) sensitiveList(c *call) {
+	words := []map[string]string{}
+	for _, w := range s.sensitiveWords() {
+		words = append(words, map[string]string{"word": w.Word, "type": w.Type, "replaceWord": w.ReplaceWord})
+	}
+	c.ok(map[string]interface{}{"words": words})
+}
+
+// sensitiveRemove 删除敏感词，每次最多 50 个，模拟服务立即生效
+func (s *Server) sensitiveRemove(c *call) {
+	if !c.required("words") {
+		return
+	}
+	words := c.params("words")
+	if len(words) > 50 {
+		c.fail(http.StatusBadRequest, CodeParam, "words exceeds 50")
+		return
+	}
+	for _, w := range words {
+		delete(s.sensitive, w)
+	}
+	c.ok(nil)
+}
+
+func (s *Server) sensitiveWords() []SensitiveWord {
+	words := make([]SensitiveWord, 0, len(s.sensitive))
+	for _, w := range s.sensitive {
+		words = append(words, w)
+	}
+	sort.Slice(words, func(i, j int) bool { return words[i].Word < words[j].Word })
+	return words
+}
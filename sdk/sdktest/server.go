@@ -0,0 +1,540 @@
+// Package sdktest 提供进程内的融云 Server API 模拟服务，用于离线测试
+//
+//	srv := sdktest.NewServer()
+//	defer srv.Close()
+//	rc := sdk.NewRongCloud(srv.AppKey, srv.AppSecret, sdk.WithRongCloudURI(srv.URL))
+//
+// 模拟服务会校验签名，在内存中保存用户、群组、聊天室、超级群和黑名单，并支持通过 Inject 注入 5xx、超时和业务错误码
+package sdktest
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAppKey 模拟服务默认的 App-Key
+	DefaultAppKey = "sdktest-app-key"
+	// DefaultAppSecret 模拟服务默认的 App-Secret
+	DefaultAppSecret = "sdktest-app-secret"
+)
+
+// 返回码
+const (
+	CodeOK        = 200   // v1 接口成功
+	CodeOKV2      = 10000 // v2 接口成功
+	CodeInternal  = 1000  // 服务内部错误
+	CodeAppSecret = 1001  // App-Key 或 App-Secret 错误
+	CodeParam     = 1002  // 参数错误
+	CodeSignature = 1004  // 签名错误
+)
+
+// User 用户
+type User struct {
+	ID          string
+	Name        string
+	PortraitURI string
+	Token       string
+	Online      bool
+	BlockUntil  time.Time // 封禁结束时间，未封禁时为零值
+}
+
+// Group 群组或超级群
+type Group struct {
+	ID      string
+	Name    string
+	Members []string // 按加入顺序排列
+}
+
+// Chatroom 聊天室
+type Chatroom struct {
+	ID        string
+	Name      string
+	Members   []string // 按加入顺序排列
+	CreatedAt time.Time
+}
+
+// Message 发送的消息
+type Message struct {
+	Type        string // private、group、chatroom、system、ultragroup、push
+	From        string
+	To          []string
+	ObjectName  string
+	Content     string
+	MessageUIDs []string // 分配的消息 ID，与 To 一一对应，push 为空
+}
+
+// SensitiveWord 敏感词
+type SensitiveWord struct {
+	Word        string
+	Type        string // 0 替换，1 屏蔽
+	ReplaceWord string
+}
+
+// Request 收到的请求
+type Request struct {
+	Method    string
+	Path      string
+	Header    http.Header
+	Form      url.Values // 表单参数及 url 参数
+	Body      []byte     // json 请求体
+	RequestID string     // v2 接口为 RC-Request-Id，v1 接口为服务端生成的 X-Request-Id
+}
+
+// Fault 注入的故障
+type Fault struct {
+	// Path 匹配的接口路径前缀，如 /user/getToken.json，为空时匹配所有请求
+	Path string
+	// Times 生效次数，0 表示一直生效
+	Times int
+	// Delay 响应前等待的时间，大于客户端超时时间即可模拟超时
+	Delay time.Duration
+	// StatusCode 返回的 http 状态码，如 500
+	StatusCode int
+	// Code 返回的业务码，StatusCode 和 Code 都为 0 时等待 Delay 后正常处理请求
+	Code int
+	// Message 返回的错误信息
+	Message string
+}
+
+// Server 模拟融云 Server API 的测试服务
+type Server struct {
+	*httptest.Server
+	AppKey    string
+	AppSecret string
+
+	mu          sync.Mutex
+	users       map[string]*User
+	blacklists  map[string][]string
+	groups      map[string]*Group
+	chatrooms   map[string]*Chatroom
+	ultragroups map[string]*Group
+	sensitive   map[string]SensitiveWord
+	messages    []Message
+	requests    []Request
+	faults      []*Fault
+	seq         int // 消息 ID、请求 ID 的序号
+}
+
+// NewServer 使用 DefaultAppKey、DefaultAppSecret 启动模拟服务，使用完需调用 Close
+func NewServer() *Server {
+	return NewServerWithKey(DefaultAppKey, DefaultAppSecret)
+}
+
+// NewServerWithKey 使用指定的 App-Key 和 App-Secret 启动模拟服务
+func NewServerWithKey(appKey, appSecret string) *Server {
+	s := &Server{
+		AppKey:    appKey,
+		AppSecret: appSecret,
+	}
+	s.Reset()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Reset 清空所有数据、请求记录和故障
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = map[string]*User{}
+	s.blacklists = map[string][]string{}
+	s.groups = map[string]*Group{}
+	s.chatrooms = map[string]*Chatroom{}
+	s.ultragroups = map[string]*Group{}
+	s.sensitive = map[string]SensitiveWord{}
+	s.messages = nil
+	s.requests = nil
+	s.faults = nil
+}
+
+// Inject 注入故障，按注入顺序匹配，同一请求只触发第一个匹配的故障
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	s.faults = append(s.faults, &f)
+	s.mu.Unlock()
+}
+
+// ClearFaults 清除所有故障
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	s.faults = nil
+	s.mu.Unlock()
+}
+
+// Requests 收到的所有请求，包括签名错误和触发故障的请求
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Messages 发送成功的所有消息
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// User 获取用户
+func (s *Server) User(id string) (User, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[id]
+	if !ok {
+		return User{}, false
+	}
+	return *u, true
+}
+
+// SetOnline 设置用户在线状态，用户不存在时自动创建
+func (s *Server) SetOnline(userId string, online bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user(userId).Online = online
+}
+
+// Blacklist 获取用户的黑名单
+func (s *Server) Blacklist(userId string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.blacklists[userId]...)
+}
+
+// Group 获取群组
+func (s *Server) Group(id string) (Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyGroup(s.groups[id])
+}
+
+// UltraGroup 获取超级群
+func (s *Server) UltraGroup(id string) (Group, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyGroup(s.ultragroups[id])
+}
+
+// Chatroom 获取聊天室
+func (s *Server) Chatroom(id string) (Chatroom, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.chatrooms[id]
+	if !ok {
+		return Chatroom{}, false
+	}
+	room := *c
+	room.Members = append([]string(nil), c.Members...)
+	return room, true
+}
+
+// SensitiveWords 获取所有敏感词，按敏感词排序
+func (s *Server) SensitiveWords() []SensitiveWord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sensitiveWords()
+}
+
+// JoinChatroom 模拟用户通过客户端加入聊天室，聊天室不存在时自动创建
+func (s *Server) JoinChatroom(id string, userIds ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.chatrooms[id]
+	if !ok {
+		c = &Chatroom{ID: id, CreatedAt: time.Now()}
+		s.chatrooms[id] = c
+	}
+	c.Members = appendUnique(c.Members, userIds...)
+}
+
+func copyGroup(g *Group) (Group, bool) {
+	if g == nil {
+		return Group{}, false
+	}
+	group := *g
+	group.Members = append([]string(nil), g.Members...)
+	return group, true
+}
+
+// user 获取用户，不存在时创建，调用方需持有锁
+func (s *Server) user(id string) *User {
+	u, ok := s.users[id]
+	if !ok {
+		u = &User{ID: id}
+		s.users[id] = u
+	}
+	return u
+}
+
+// call 一次请求的上下文
+type call struct {
+	w       http.ResponseWriter
+	r       *http.Request
+	req     Request
+	v2      bool // 是否按 v2 接口返回
+	written bool // 是否已经返回
+}
+
+// param 获取表单参数
+func (c *call) param(key string) string {
+	return c.req.Form.Get(key)
+}
+
+// params 获取多值表单参数
+func (c *call) params(key string) []string {
+	return c.req.Form[key]
+}
+
+// decode 解析 json 请求体
+func (c *call) decode(v interface{}) bool {
+	if err := json.Unmarshal(c.req.Body, v); err != nil {
+		c.fail(http.StatusBadRequest, CodeParam, "invalid json body")
+		return false
+	}
+	return true
+}
+
+// ok 返回成功，fields 为附加的返回字段
+func (c *call) ok(fields map[string]interface{}) {
+	resp := map[string]interface{}{}
+	for k, v := range fields {
+		resp[k] = v
+	}
+	if c.v2 {
+		resp["code"] = CodeOKV2
+	} else {
+		resp["code"] = CodeOK
+	}
+	c.write(http.StatusOK, resp)
+}
+
+// fail 返回错误
+func (c *call) fail(status, code int, msg string) {
+	resp := map[string]interface{}{"code": code}
+	if c.v2 {
+		resp["msg"] = msg
+	} else {
+		resp["errorMessage"] = msg
+	}
+	c.write(status, resp)
+}
+
+// required 检查必填参数，缺少时返回 1002
+func (c *call) required(keys ...string) bool {
+	for _, key := range keys {
+		if c.param(key) == "" {
+			c.fail(http.StatusBadRequest, CodeParam, fmt.Sprintf("%s is required", key))
+			return false
+		}
+	}
+	return true
+}
+
+// max 检查参数个数上限，超出时返回 1002，n 为 0 时不限制
+func (c *call) max(key string, n int) bool {
+	if n > 0 && len(c.params(key)) > n {
+		c.fail(http.StatusBadRequest, CodeParam, fmt.Sprintf("%s exceeds %d", key, n))
+		return false
+	}
+	return true
+}
+
+func (c *call) write(status int, v interface{}) {
+	c.written = true
+	c.w.Header().Set("Content-Type", "application/json")
+	c.w.WriteHeader(status)
+	_ = json.NewEncoder(c.w).Encode(v)
+}
+
+// nextID 生成唯一 ID，调用时需持有 s.mu
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s%08d", prefix, s.seq)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(io.LimitReader(r.Body, 10<<20))
+	c := &call{
+		w: w,
+		r: r,
+		req: Request{
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			Header:    r.Header.Clone(),
+			Form:      r.URL.Query(),
+			RequestID: r.Header.Get("RC-Request-Id"),
+		},
+		v2: strings.HasPrefix(r.URL.Path, "/v2/"),
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		form, _ := url.ParseQuery(string(body))
+		for k, v := range form {
+			c.req.Form[k] = append(c.req.Form[k], v...)
+		}
+	} else {
+		c.req.Body = body
+	}
+
+	s.mu.Lock()
+	if !c.v2 {
+		c.req.RequestID = s.nextID("req-")
+		w.Header().Set("X-Request-Id", c.req.RequestID)
+	}
+	s.requests = append(s.requests, c.req)
+	s.mu.Unlock()
+	if !s.verify(c) {
+		return
+	}
+
+	s.mu.Lock()
+	fault := s.matchFault(r.URL.Path)
+	s.mu.Unlock()
+	if fault != nil {
+		if fault.Delay > 0 {
+			timer := time.NewTimer(fault.Delay)
+			select {
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		if fault.StatusCode != 0 || fault.Code != 0 {
+			status, code := fault.StatusCode, fault.Code
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if code == 0 {
+				code = CodeInternal
+			}
+			msg := fault.Message
+			if msg == "" {
+				msg = "injected fault"
+			}
+			c.fail(status, code, msg)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c.v2 {
+		s.serveV2(c)
+		return
+	}
+	handler, ok := handlers[r.URL.Path]
+	if !ok {
+		c.fail(http.StatusNotFound, CodeParam, "unsupported api "+r.URL.Path)
+		return
+	}
+	handler(s, c)
+}
+
+// matchFault 获取匹配的故障并扣减次数，调用方需持有锁
+func (s *Server) matchFault(path string) *Fault {
+	for i, f := range s.faults {
+		if f.Path != "" && !strings.HasPrefix(path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// verify 校验 App-Key 和签名，v2 接口使用 RC- 前缀的请求头
+func (s *Server) verify(c *call) bool {
+	prefix := ""
+	if c.r.Header.Get("RC-App-Key") != "" {
+		prefix = "RC-"
+	}
+	h := c.r.Header
+	if h.Get(prefix+"App-Key") != s.AppKey {
+		c.fail(http.StatusUnauthorized, CodeAppSecret, "invalid App-Key")
+		return false
+	}
+	nonce, timestamp := h.Get(prefix+"Nonce"), h.Get(prefix+"Timestamp")
+	if h.Get(prefix+"Signature") != Signature(s.AppSecret, nonce, timestamp) {
+		c.fail(http.StatusUnauthorized, CodeSignature, "invalid signature")
+		return false
+	}
+	return true
+}
+
+// Signature 计算签名：App-Secret、Nonce、Timestamp 拼接后的 SHA1 哈希
+func Signature(appSecret, nonce, timestamp string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(appSecret+nonce+timestamp)))
+}
+
+// appendUnique 追加不存在的元素
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		if !contains(list, item) {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// remove 删除元素
+func remove(list []string, items ...string) []string {
+	result := list[:0]
+	for _, v := range list {
+		if !contains(items, v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+// page 按页码截取，page 从 1 开始
+func page(list []string, page, size int) []string {
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 20
+	}
+	start := (page - 1) * size
+	if start >= len(list) {
+		return nil
+	}
+	end := start + size
+	if end > len(list) {
+		end = len(list)
+	}
+	return list[start:end]
+}
+
+// sortedKeys map 的有序 key
+func sortedKeys(m map[string]*Group) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
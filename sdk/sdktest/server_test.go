@@ -0,0 +1,217 @@
+package sdktest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk"
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+func newClient(srv *sdktest.Server) *sdk.RongCloud {
+	return sdk.NewRongCloud(srv.AppKey, srv.AppSecret, sdk.WithRongCloudURI(srv.URL))
+}
+
+func TestServer_user(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := newClient(srv)
+
+	user, err := rc.UserRegister("u01", "name", "http://example.com/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.UserID != "u01" || user.Token == "" {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if err := rc.UserUpdate("u01", "new name", ""); err != nil {
+		t.Fatal(err)
+	}
+	if u, ok := srv.User("u01"); !ok || u.Name != "new name" || u.PortraitURI != "http://example.com/a.png" {
+		t.Errorf("unexpected user %+v", u)
+	}
+
+	srv.SetOnline("u01", true)
+	if status, err := rc.OnlineStatusCheck("u01"); err != nil || status != 1 {
+		t.Errorf("expect online, got %d %v", status, err)
+	}
+
+	if err := rc.BlacklistAdd("u01", []string{"u02", "u03"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.BlacklistRemove("u01", []string{"u02"}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := rc.BlacklistGet("u01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Users) != 1 || list.Users[0] != "u03" {
+		t.Errorf("unexpected blacklist %v", list.Users)
+	}
+}
+
+func TestServer_group(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := newClient(srv)
+
+	if err := rc.GroupCreate("g01", "group", []string{"u01", "u02"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupJoin("g01", "", "u03"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupQuit([]string{"u01"}, "g01"); err != nil {
+		t.Fatal(err)
+	}
+	group, err := rc.GroupGet("g01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Users) != 2 || group.Users[0].ID != "u02" || group.Users[1].ID != "u03" {
+		t.Errorf("unexpected members %+v", group.Users)
+	}
+
+	msg := sdk.TXTMsg{Content: "hello"}
+	if err := rc.GroupSend("u02", []string{"g01"}, nil, "RC:TxtMsg", &msg, "", "", 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	messages := srv.Messages()
+	if len(messages) != 1 || messages[0].Type != "group" || messages[0].To[0] != "g01" || messages[0].ObjectName != "RC:TxtMsg" {
+		t.Errorf("unexpected messages %+v", messages)
+	}
+}
+
+func TestServer_chatroom(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := newClient(srv)
+
+	if err := rc.ChatRoomCreate("c01", "room"); err != nil {
+		t.Fatal(err)
+	}
+	srv.JoinChatroom("c01", "u01", "u02")
+	rooms, err := rc.ChatRoomQuery([]string{"c01", "c02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rooms) != 1 || rooms[0].Name != "room" {
+		t.Errorf("unexpected chatrooms %+v", rooms)
+	}
+	result, err := rc.ChatRoomGet("c01", 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 2 || result.Users[0].ID != "u02" {
+		t.Errorf("unexpected members %+v", result)
+	}
+	exist, err := rc.ChatUserExistResObj("c01", "u01")
+	if err != nil || !exist.IsInChrm {
+		t.Errorf("expect u01 in chatroom, got %+v %v", exist, err)
+	}
+	if err := rc.ChatRoomDestroy("c01"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.Chatroom("c01"); ok {
+		t.Error("chatroom should be destroyed")
+	}
+}
+
+func TestServer_ultragroup(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := newClient(srv)
+
+	if err, _ := rc.UGGroupCreate("u01", "ug01", "ultra"); err != nil {
+		t.Fatal(err)
+	}
+	if err, _ := rc.UGGroupJoin("u02", "ug01"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.UltraGroupJoin("u03", "ug01"); err != nil {
+		t.Fatal(err)
+	}
+	users, err, _ := rc.UGQueryGroupUsers("ug01", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Id != "u01" || users[1].Id != "u02" {
+		t.Errorf("unexpected users %+v", users)
+	}
+	groups, err, _ := rc.UGQueryUserGroups("u03", 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[0].GroupName != "ultra" {
+		t.Errorf("unexpected groups %+v", groups)
+	}
+	if exists, err := rc.UGMemberExists("ug01", "u02"); err != nil || !exists {
+		t.Errorf("expect u02 in ultragroup, got %v %v", exists, err)
+	}
+	if err, _ := rc.UGGroupDismiss("ug01"); err != nil {
+		t.Fatal(err)
+	}
+	if err, _ := rc.UGGroupDismiss("ug01"); err == nil {
+		t.Error("expect error dismissing a missing ultragroup")
+	}
+}
+
+func TestServer_signature(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+
+	rc := sdk.NewRongCloud(srv.AppKey, "wrong secret", sdk.WithRongCloudURI(srv.URL))
+	_, err := rc.UserRegister("u01", "name", "")
+	if !errors.Is(err, sdk.ErrSignature) {
+		t.Fatalf("expect ErrSignature, got %v", err)
+	}
+	if _, ok := srv.User("u01"); ok {
+		t.Error("user should not be registered")
+	}
+	if err, _ := rc.UGGroupCreate("u01", "ug01", "ultra"); !errors.Is(err, sdk.ErrSignature) {
+		t.Errorf("expect ErrSignature for v2 api, got %v", err)
+	}
+}
+
+func TestServer_faults(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+
+	policy := sdk.DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	rc := sdk.NewRongCloud(srv.AppKey, srv.AppSecret, sdk.WithRongCloudURI(srv.URL), sdk.WithRetryPolicy(policy))
+
+	// 5xx 后重试成功
+	srv.Inject(sdktest.Fault{Path: "/v2/ultragroups", Times: 2, StatusCode: http.StatusInternalServerError})
+	if err, _ := rc.UGGroupCreate("u01", "ug01", "ultra"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("expect 3 requests, got %d", n)
+	}
+
+	// 业务错误码
+	srv.Inject(sdktest.Fault{Path: "/user/getToken.json", Times: 1, Code: 1008})
+	if _, err := rc.UserRegister("u01", "name", ""); !errors.Is(err, sdk.ErrRateLimited) {
+		t.Errorf("expect ErrRateLimited, got %v", err)
+	}
+	if _, err := rc.UserRegister("u01", "name", ""); err != nil {
+		t.Errorf("fault should be consumed, got %v", err)
+	}
+
+	// 超时
+	srv.Inject(sdktest.Fault{Delay: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := rc.WithContext(ctx).UserRegister("u01", "name", ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expect context.DeadlineExceeded, got %v", err)
+	}
+	srv.ClearFaults()
+	if _, err := rc.UserRegister("u01", "name", ""); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,146 @@
+package sdktest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// serveV2 处理 /v2 json 接口，调用时已持有 s.mu
+func (s *Server) serveV2(c *call) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(c.r.URL.Path, "/v2/"), "/"), "/")
+	method := c.r.Method
+	switch {
+	case len(segments) == 3 && segments[0] == "message" && segments[1] == "ultragroup" && segments[2] == "send" && method == http.MethodPost:
+		s.v2UltragroupSend(c)
+	case segments[0] != "ultragroups":
+	case len(segments) == 1 && method == http.MethodPost:
+		s.v2UltragroupCreate(c)
+	case len(segments) == 4 && segments[1] == "users" && segments[3] == "groups" && method == http.MethodGet:
+		s.v2UserGroups(c, segments[2])
+	case len(segments) == 2 && method == http.MethodDelete:
+		if !s.dismissUltragroup(segments[1]) {
+			c.fail(http.StatusNotFound, CodeParam, "group not exist")
+			return
+		}
+		c.ok(nil)
+	case len(segments) == 2 && method == http.MethodPut:
+		s.v2UltragroupUpdate(c, segments[1])
+	case len(segments) == 3 && segments[2] == "users" && method == http.MethodGet:
+		s.v2UltragroupUsers(c, segments[1])
+	case len(segments) == 4 && segments[2] == "users":
+		s.v2UltragroupMember(c, segments[1], segments[3])
+	default:
+	}
+	if !c.written {
+		c.fail(http.StatusNotFound, CodeParam, "unsupported api "+c.r.Method+" "+c.r.URL.Path)
+	}
+}
+
+func (s *Server) v2UltragroupCreate(c *call) {
+	var body struct {
+		UserId    string `json:"user_id"`
+		GroupId   string `json:"group_id"`
+		GroupName string `json:"group_name"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	if body.UserId == "" || body.GroupId == "" || body.GroupName == "" {
+		c.fail(http.StatusBadRequest, CodeParam, "user_id, group_id and group_name are required")
+		return
+	}
+	s.createUltragroup(body.GroupId, body.GroupName, body.UserId)
+	c.ok(nil)
+}
+
+func (s *Server) v2UltragroupUpdate(c *call, groupId string) {
+	var body struct {
+		GroupName string `json:"group_name"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	g, ok := s.ultragroups[groupId]
+	if !ok {
+		c.fail(http.StatusNotFound, CodeParam, "group not exist")
+		return
+	}
+	g.Name = body.GroupName
+	c.ok(nil)
+}
+
+// v2UltragroupMember POST 加入超级群，DELETE 退出超级群
+func (s *Server) v2UltragroupMember(c *call, groupId, userId string) {
+	g, ok := s.ultragroups[groupId]
+	if !ok {
+		c.fail(http.StatusNotFound, CodeParam, "group not exist")
+		return
+	}
+	switch c.r.Method {
+	case http.MethodPost:
+		g.Members = appendUnique(g.Members, userId)
+	case http.MethodDelete:
+		g.Members = remove(g.Members, userId)
+	default:
+		return
+	}
+	c.ok(nil)
+}
+
+func (s *Server) v2UltragroupUsers(c *call, groupId string) {
+	g, ok := s.ultragroups[groupId]
+	if !ok {
+		c.fail(http.StatusNotFound, CodeParam, "group not exist")
+		return
+	}
+	users := []map[string]interface{}{}
+	for _, id := range page(g.Members, c.intParam("page"), c.intParam("size")) {
+		users = append(users, map[string]interface{}{"id": id})
+	}
+	c.ok(map[string]interface{}{"data": map[string]interface{}{"users": users}})
+}
+
+func (s *Server) v2UserGroups(c *call, userId string) {
+	var ids []string
+	for _, id := range sortedKeys(s.ultragroups) {
+		if contains(s.ultragroups[id].Members, userId) {
+			ids = append(ids, id)
+		}
+	}
+	groups := []map[string]interface{}{}
+	for _, id := range page(ids, c.intParam("page"), c.intParam("size")) {
+		groups = append(groups, map[string]interface{}{"group_id": id, "group_name": s.ultragroups[id].Name})
+	}
+	c.ok(map[string]interface{}{"data": map[string]interface{}{"groups": groups}})
+}
+
+func (s *Server) v2UltragroupSend(c *call) {
+	var body struct {
+		FromUserId string   `json:"from_user_id"`
+		ToGroupIds []string `json:"to_group_ids"`
+		ObjectName string   `json:"object_name"`
+		Content    string   `json:"content"`
+	}
+	if !c.decode(&body) {
+		return
+	}
+	if body.FromUserId == "" || len(body.ToGroupIds) == 0 {
+		c.fail(http.StatusBadRequest, CodeParam, "from_user_id and to_group_ids are required")
+		return
+	}
+	s.messages = append(s.messages, Message{
+		Type:       "ultragroup",
+		From:       body.FromUserId,
+		To:         body.ToGroupIds,
+		ObjectName: body.ObjectName,
+		Content:    body.Content,
+	})
+	c.ok(nil)
+}
+
+// intParam 获取整数参数，无法解析时返回 0
+func (c *call) intParam(key string) int {
+	n, _ := strconv.Atoi(c.param(key))
+	return n
+}
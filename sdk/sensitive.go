@@ -2,8 +2,8 @@ package sdk
 
 import (
 	"encoding/json"
-	"github.com/astaxie/beego/httplib"
-	"time"
+	"net/http"
+	"strconv"
 )
 
 // ListWordFilterResult listWordFilter返回结果
@@ -33,8 +33,12 @@ func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) er
 	if replace == "" {
 		return RCErrorNew(1002, "Paramer 'replace' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	return rc.sensitiveAdd(keyword, replace, sensitiveType)
+}
+
+// sensitiveAdd 添加敏感词，屏蔽词（sensitiveType 为 1）不发送替换词，replace 可以为空
+func (rc *RongCloud) sensitiveAdd(keyword, replace string, sensitiveType int) error {
+	req := rc.newRequest("SensitiveAdd", http.MethodPost, "/sensitiveword/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("word", keyword)
 	switch sensitiveType {
@@ -49,6 +53,12 @@ func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) er
 	_, err := rc.do(req)
 	if err != nil {
 		rc.urlError(err)
+	} else if rc.sensitiveFilter != nil {
+		word := SensitiveWord{Type: strconv.Itoa(sensitiveType), Word: keyword}
+		if sensitiveType == 0 {
+			word.ReplaceWord = replace
+		}
+		rc.sensitiveFilter.Add(word)
 	}
 	return err
 }
@@ -59,8 +69,7 @@ func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) er
  */
 func (rc *RongCloud) SensitiveGetList() (ListWordFilterResult, error) {
 
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("SensitiveGetList", http.MethodPost, "/sensitiveword/list."+ReqType)
 	rc.fillHeader(req)
 
 	resp, err := rc.do(req)
@@ -88,8 +97,7 @@ func (rc *RongCloud) SensitiveRemove(keywords []string) error {
 		return RCErrorNew(1002, "Paramer 'keywords' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/batch/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("SensitiveRemove", http.MethodPost, "/sensitiveword/batch/delete."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range keywords {
 		req.Param("words", v)
@@ -98,6 +106,8 @@ func (rc *RongCloud) SensitiveRemove(keywords []string) error {
 	_, err := rc.do(req)
 	if err != nil {
 		rc.urlError(err)
+	} else if rc.sensitiveFilter != nil {
+		rc.sensitiveFilter.Remove(keywords...)
 	}
 	return err
 
@@ -0,0 +1,274 @@
+package sdk
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	SensitiveReplace = 0 // SensitiveReplace 敏感词替换
+	SensitiveBlock   = 1 // SensitiveBlock 敏感词屏蔽
+	// SENSITIVE_DEFAULT_REPLACE 替换词为空的替换类敏感词使用的默认替换内容
+	SENSITIVE_DEFAULT_REPLACE = "***"
+)
+
+// SensitiveMatch 文本中命中的一个敏感词
+type SensitiveMatch struct {
+	Word        string // 敏感词
+	Type        int    // SensitiveReplace 或 SensitiveBlock
+	ReplaceWord string // 替换词，屏蔽词为空
+	Start       int    // 命中内容在原文中的起始字节位置
+	End         int    // 命中内容在原文中的结束字节位置（不含）
+}
+
+// acNode Aho-Corasick 自动机节点
+type acNode struct {
+	next  map[rune]*acNode
+	fail  *acNode
+	entry *sensitiveEntry // 以该节点结尾的敏感词
+	// link 沿失败指针找到的最近的一个以敏感词结尾的节点
+	link *acNode
+}
+
+type sensitiveEntry struct {
+	word   SensitiveWord
+	typ    int
+	length int // 按字符计的长度
+}
+
+// acAutomaton 构建完成后只读的自动机，可以并发匹配
+type acAutomaton struct {
+	root *acNode
+}
+
+func newACAutomaton(words map[string]*sensitiveEntry) *acAutomaton {
+	root := &acNode{next: map[rune]*acNode{}}
+	for key, entry := range words {
+		node := root
+		for _, r := range key {
+			child, ok := node.next[r]
+			if !ok {
+				child = &acNode{next: map[rune]*acNode{}}
+				node.next[r] = child
+			}
+			node = child
+		}
+		node.entry = entry
+	}
+
+	// 按层构建失败指针
+	queue := make([]*acNode, 0, len(root.next))
+	for _, child := range root.next {
+		child.fail = root
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.fail.entry != nil {
+			node.link = node.fail
+		} else {
+			node.link = node.fail.link
+		}
+		for r, child := range node.next {
+			fail := node.fail
+			for fail != nil && fail.next[r] == nil {
+				fail = fail.fail
+			}
+			if fail == nil {
+				child.fail = root
+			} else {
+				child.fail = fail.next[r]
+			}
+			queue = append(queue, child)
+		}
+	}
+	return &acAutomaton{root: root}
+}
+
+// match 返回文本中所有命中的敏感词，可能重叠
+func (a *acAutomaton) match(text string) []SensitiveMatch {
+	var matches []SensitiveMatch
+	// offsets 记录最近字符的起始字节位置，用于计算命中内容的起始位置
+	var offsets []int
+	node := a.root
+	for i, r := range text {
+		offsets = append(offsets, i)
+		r = foldSensitive(r)
+		for node != a.root && node.next[r] == nil {
+			node = node.fail
+		}
+		if next, ok := node.next[r]; ok {
+			node = next
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		end := i + size
+		out := node
+		if out.entry == nil {
+			out = out.link
+		}
+		for ; out != nil; out = out.link {
+			e := out.entry
+			matches = append(matches, SensitiveMatch{
+				Word:        e.word.Word,
+				Type:        e.typ,
+				ReplaceWord: e.word.ReplaceWord,
+				Start:       offsets[len(offsets)-e.length],
+				End:         end,
+			})
+		}
+	}
+	return matches
+}
+
+// foldSensitive 匹配时不区分大小写
+func foldSensitive(r rune) rune {
+	return unicode.ToLower(r)
+}
+
+func foldSensitiveWord(word string) string {
+	return strings.Map(foldSensitive, word)
+}
+
+// SensitiveFilter 本地敏感词过滤，与服务端使用相同的替换（type 0）和屏蔽（type 1）规则
+// 可以通过 SensitiveFilterSync 从服务端敏感词列表初始化，匹配时不区分大小写
+type SensitiveFilter struct {
+	mu        sync.RWMutex
+	words     map[string]*sensitiveEntry // key 为转为小写的敏感词
+	automaton *acAutomaton
+}
+
+// NewSensitiveFilter 创建本地敏感词过滤
+func NewSensitiveFilter(words ...SensitiveWord) *SensitiveFilter {
+	f := &SensitiveFilter{words: map[string]*sensitiveEntry{}}
+	f.Add(words...)
+	return f
+}
+
+// Add 添加或更新敏感词，Word 为空的敏感词被忽略，替换类敏感词的 ReplaceWord 为空时替换为 SENSITIVE_DEFAULT_REPLACE
+func (f *SensitiveFilter) Add(words ...SensitiveWord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.add(words)
+}
+
+func (f *SensitiveFilter) add(words []SensitiveWord) {
+	for _, w := range words {
+		key := foldSensitiveWord(w.Word)
+		if key == "" {
+			continue
+		}
+		typ, _ := strconv.Atoi(w.Type)
+		if typ == SensitiveReplace && w.ReplaceWord == "" {
+			w.ReplaceWord = SENSITIVE_DEFAULT_REPLACE
+		}
+		f.words[key] = &sensitiveEntry{word: w, typ: typ, length: utf8.RuneCountInString(key)}
+	}
+	f.automaton = newACAutomaton(f.words)
+}
+
+// Remove 删除敏感词，本地立即生效。
+// 服务端 SensitiveRemove 需要 2 小时后生效，在此期间本地已不再过滤该词而服务端仍会替换或屏蔽，
+// 需要与服务端保持一致时可以在 2 小时后再调用 Remove
+func (f *SensitiveFilter) Remove(words ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, w := range words {
+		delete(f.words, foldSensitiveWord(w))
+	}
+	f.automaton = newACAutomaton(f.words)
+}
+
+// Reset 使用 words 替换全部敏感词
+func (f *SensitiveFilter) Reset(words []SensitiveWord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.words = map[string]*sensitiveEntry{}
+	f.add(words)
+}
+
+// Words 当前的敏感词，按敏感词排序
+func (f *SensitiveFilter) Words() []SensitiveWord {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	words := make([]SensitiveWord, 0, len(f.words))
+	for _, e := range f.words {
+		words = append(words, e.word)
+	}
+	sort.Slice(words, func(i, j int) bool { return words[i].Word < words[j].Word })
+	return words
+}
+
+// Match 返回文本中命中的敏感词，命中内容重叠时取起始位置靠前、长度最长的
+func (f *SensitiveFilter) Match(text string) []SensitiveMatch {
+	f.mu.RLock()
+	a := f.automaton
+	f.mu.RUnlock()
+
+	matches := a.match(text)
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+	result := matches[:0]
+	end := 0
+	for _, m := range matches {
+		if m.Start < end {
+			continue
+		}
+		result = append(result, m)
+		end = m.End
+	}
+	return result
+}
+
+// Filter 过滤文本，命中屏蔽词时 blocked 为 true，否则返回替换敏感词后的文本
+func (f *SensitiveFilter) Filter(text string) (result string, blocked bool, matches []SensitiveMatch) {
+	matches = f.Match(text)
+	if len(matches) == 0 {
+		return text, false, nil
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m.Type == SensitiveBlock {
+			blocked = true
+			continue
+		}
+		b.WriteString(text[last:m.Start])
+		b.WriteString(m.ReplaceWord)
+		last = m.End
+	}
+	b.WriteString(text[last:])
+	return b.String(), blocked, matches
+}
+
+// FilterTXTMsg 过滤文本消息，替换 msg.Content 中的敏感词。命中屏蔽词时 blocked 为 true，不应再发送该消息
+func (f *SensitiveFilter) FilterTXTMsg(msg *TXTMsg) (blocked bool, matches []SensitiveMatch) {
+	msg.Content, blocked, matches = f.Filter(msg.Content)
+	return blocked, matches
+}
+
+// WithSensitiveFilter 关联本地敏感词过滤，SensitiveAdd、SensitiveRemove 调用成功后同步更新 f
+// 需要先调用 SensitiveFilterSync 从服务端敏感词列表初始化。SensitiveRemove 删除的词在本地立即失效，早于服务端的 2 小时，见 Remove
+func WithSensitiveFilter(f *SensitiveFilter) rongCloudOption {
+	return func(o *RongCloud) {
+		o.sensitiveFilter = f
+	}
+}
+
+// SensitiveFilterSync 使用服务端敏感词列表替换本地敏感词过滤中的全部敏感词
+func (rc *RongCloud) SensitiveFilterSync(f *SensitiveFilter) error {
+	list, err := rc.SensitiveGetList()
+	if err != nil {
+		return err
+	}
+	f.Reset(list.Words)
+	return nil
+}
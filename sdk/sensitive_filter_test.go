@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+func TestSensitiveFilter_Filter(t *testing.T) {
+	f := NewSensitiveFilter(
+		SensitiveWord{Type: "0", Word: "bad", ReplaceWord: "***"},
+		SensitiveWord{Type: "0", Word: "badword", ReplaceWord: "#"},
+		SensitiveWord{Type: "0", Word: "敏感", ReplaceWord: "**"},
+		SensitiveWord{Type: "1", Word: "spam"},
+	)
+	cases := []struct {
+		text    string
+		result  string
+		blocked bool
+		words   []string
+	}{
+		{"hello", "hello", false, nil},
+		{"a BAD day", "a *** day", false, []string{"bad"}},
+		{"a badword, bad", "a #, ***", false, []string{"badword", "bad"}},
+		{"这是敏感内容", "这是**内容", false, []string{"敏感"}},
+		{"buy SPAM now, bad", "buy SPAM now, ***", true, []string{"spam", "bad"}},
+	}
+	for _, c := range cases {
+		result, blocked, matches := f.Filter(c.text)
+		if result != c.result || blocked != c.blocked || len(matches) != len(c.words) {
+			t.Errorf("%s: got %q %v %+v", c.text, result, blocked, matches)
+			continue
+		}
+		for i, m := range matches {
+			if m.Word != c.words[i] {
+				t.Errorf("%s: expect %s, got %s", c.text, c.words[i], m.Word)
+			}
+		}
+	}
+
+	msg := TXTMsg{Content: "这是敏感内容"}
+	if blocked, _ := f.FilterTXTMsg(&msg); blocked || msg.Content != "这是**内容" {
+		t.Errorf("unexpected msg %+v", msg)
+	}
+
+	f.Remove("BAD", "spam")
+	if _, blocked, matches := f.Filter("bad spam"); blocked || len(matches) != 0 {
+		t.Errorf("removed words should not match, got %+v", matches)
+	}
+}
+
+func TestSensitiveFilter_overlap(t *testing.T) {
+	f := NewSensitiveFilter(
+		SensitiveWord{Type: "0", Word: "he", ReplaceWord: "1"},
+		SensitiveWord{Type: "0", Word: "she", ReplaceWord: "2"},
+		SensitiveWord{Type: "0", Word: "hers", ReplaceWord: "3"},
+	)
+	if result, _, _ := f.Filter("ushers"); result != "u2rs" {
+		t.Errorf("expect u2rs, got %s", result)
+	}
+	if result, _, _ := f.Filter("hershe"); result != "31" {
+		t.Errorf("expect 31, got %s", result)
+	}
+}
+
+func TestSensitiveFilter_defaultReplace(t *testing.T) {
+	f := NewSensitiveFilter(SensitiveWord{Type: "0", Word: "bad"})
+	if result, _, matches := f.Filter("a bad day"); result != "a "+SENSITIVE_DEFAULT_REPLACE+" day" ||
+		matches[0].ReplaceWord != SENSITIVE_DEFAULT_REPLACE {
+		t.Errorf("expect default replace word, got %q", result)
+	}
+}
+
+func TestSensitiveFilterSync(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	f := NewSensitiveFilter()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithSensitiveFilter(f))
+
+	if err := rc.SensitiveAdd("bad", "***", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.SensitiveAdd("spam", "-", 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Words()) != 2 {
+		t.Fatalf("expect 2 words, got %+v", f.Words())
+	}
+
+	other := NewSensitiveFilter(SensitiveWord{Type: "1", Word: "stale"})
+	if err := rc.SensitiveFilterSync(other); err != nil {
+		t.Fatal(err)
+	}
+	words := other.Words()
+	if len(words) != 2 || words[0].Word != "bad" || words[0].ReplaceWord != "***" || words[1].Type != "1" {
+		t.Errorf("unexpected words %+v", words)
+	}
+
+	if err := rc.SensitiveRemove([]string{"bad"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, matches := f.Filter("bad"); len(matches) != 0 {
+		t.Errorf("removed word should not match, got %+v", matches)
+	}
+}
@@ -0,0 +1,241 @@
+package sdk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// SENSITIVE_WORD_MAX_LENGTH 敏感词、替换词的最大长度
+	SENSITIVE_WORD_MAX_LENGTH = 32
+	// SENSITIVE_REMOVE_BATCH SensitiveRemove 每次最多删除的敏感词个数
+	SENSITIVE_REMOVE_BATCH = 50
+)
+
+// SensitiveWordError 校验或同步失败的敏感词
+type SensitiveWordError struct {
+	Word SensitiveWord
+	Err  error
+}
+
+func (e SensitiveWordError) Error() string {
+	return fmt.Sprintf("sensitive word %q: %v", e.Word.Word, e.Err)
+}
+
+// SensitiveSyncReport 敏感词同步结果
+type SensitiveSyncReport struct {
+	DryRun  bool                 // 为 true 时只计算差异，没有调用添加、删除接口
+	Add     []SensitiveWord      // 服务端没有的敏感词
+	Update  []SensitiveWord      // 类型或替换词有变化的敏感词
+	Remove  []string             // 服务端多余的敏感词
+	Invalid []SensitiveWordError // 校验失败、没有同步的敏感词
+	Failed  []SensitiveWordError // 调用接口失败的敏感词
+}
+
+// ParseSensitiveWordsCSV 从 CSV 读取敏感词，每行为 word,type,replaceWord
+// 第一行第一列为 word 时视为表头；type 为空时有替换词为 0（替换），否则为 1（屏蔽）
+func ParseSensitiveWordsCSV(r io.Reader) ([]SensitiveWord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && len(records[0]) > 0 && strings.EqualFold(strings.TrimSpace(records[0][0]), "word") {
+		records = records[1:]
+	}
+	words := make([]SensitiveWord, 0, len(records))
+	for _, record := range records {
+		field := func(i int) string {
+			if i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if field(0) == "" && field(1) == "" && field(2) == "" {
+			continue
+		}
+		words = append(words, normalizeSensitiveWord(SensitiveWord{Word: field(0), Type: field(1), ReplaceWord: field(2)}))
+	}
+	return words, nil
+}
+
+// ParseSensitiveWordsJSON 从 JSON 数组读取敏感词，格式与 SensitiveGetList 返回的 words 一致，type 可以是数字
+func ParseSensitiveWordsJSON(r io.Reader) ([]SensitiveWord, error) {
+	var raws []struct {
+		Word        string      `json:"word"`
+		Type        json.Number `json:"type"`
+		ReplaceWord string      `json:"replaceWord"`
+	}
+	if err := json.NewDecoder(r).Decode(&raws); err != nil {
+		return nil, err
+	}
+	words := make([]SensitiveWord, 0, len(raws))
+	for _, raw := range raws {
+		words = append(words, normalizeSensitiveWord(SensitiveWord{Word: raw.Word, Type: raw.Type.String(), ReplaceWord: raw.ReplaceWord}))
+	}
+	return words, nil
+}
+
+// normalizeSensitiveWord 去掉首尾空白，补全 type
+func normalizeSensitiveWord(w SensitiveWord) SensitiveWord {
+	w.Word = strings.TrimSpace(w.Word)
+	w.Type = strings.TrimSpace(w.Type)
+	w.ReplaceWord = strings.TrimSpace(w.ReplaceWord)
+	if w.Type == "" {
+		if w.ReplaceWord != "" {
+			w.Type = "0"
+		} else {
+			w.Type = "1"
+		}
+	}
+	return w
+}
+
+// ValidateSensitiveWord 按 SensitiveAdd 的要求校验敏感词：
+// 敏感词最长 32 个字符，只能包含汉字、数字、字母；type 为 0 或 1，type 为 0 时替换词必填且最长 32 个字符
+func ValidateSensitiveWord(w SensitiveWord) error {
+	if w.Word == "" {
+		return RCErrorNew(1002, "Paramer 'word' is required")
+	}
+	if utf8.RuneCountInString(w.Word) > SENSITIVE_WORD_MAX_LENGTH {
+		return RCErrorNew(1002, fmt.Sprintf("Paramer 'word' exceeds %d characters", SENSITIVE_WORD_MAX_LENGTH))
+	}
+	for _, r := range w.Word {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return RCErrorNew(1002, fmt.Sprintf("Paramer 'word' contains illegal character %q", r))
+		}
+	}
+	switch w.Type {
+	case "0":
+		if w.ReplaceWord == "" {
+			return RCErrorNew(1002, "Paramer 'replaceWord' is required")
+		}
+		if utf8.RuneCountInString(w.ReplaceWord) > SENSITIVE_WORD_MAX_LENGTH {
+			return RCErrorNew(1002, fmt.Sprintf("Paramer 'replaceWord' exceeds %d characters", SENSITIVE_WORD_MAX_LENGTH))
+		}
+	case "1":
+	default:
+		return RCErrorNew(1002, fmt.Sprintf("Paramer 'type' is invalid: %s", w.Type))
+	}
+	return nil
+}
+
+// DiffSensitiveWords 计算从 current 同步到 desired 需要的变更，desired 中重复的敏感词以后面的为准
+// 结果的 DryRun 为 true
+func DiffSensitiveWords(current, desired []SensitiveWord) *SensitiveSyncReport {
+	report := &SensitiveSyncReport{DryRun: true}
+	existing := make(map[string]SensitiveWord, len(current))
+	for _, w := range current {
+		existing[w.Word] = normalizeSensitiveWord(w)
+	}
+
+	wanted := map[string]SensitiveWord{}
+	var order []string
+	for _, w := range desired {
+		w = normalizeSensitiveWord(w)
+		if err := ValidateSensitiveWord(w); err != nil {
+			report.Invalid = append(report.Invalid, SensitiveWordError{Word: w, Err: err})
+			continue
+		}
+		if _, ok := wanted[w.Word]; !ok {
+			order = append(order, w.Word)
+		}
+		wanted[w.Word] = w
+	}
+	for _, word := range order {
+		w := wanted[word]
+		old, ok := existing[word]
+		switch {
+		case !ok:
+			report.Add = append(report.Add, w)
+		case old.Type != w.Type || w.Type == "0" && old.ReplaceWord != w.ReplaceWord:
+			report.Update = append(report.Update, w)
+		}
+	}
+
+	// 校验失败的敏感词不会被删除，避免表格中写错一个字符就删除了服务端的敏感词
+	invalid := map[string]bool{}
+	for _, e := range report.Invalid {
+		invalid[e.Word.Word] = true
+	}
+	for word := range existing {
+		if _, ok := wanted[word]; !ok && !invalid[word] {
+			report.Remove = append(report.Remove, word)
+		}
+	}
+	sort.Strings(report.Remove)
+	return report
+}
+
+// sensitiveSyncQuotas 同步时 SensitiveAdd、SensitiveRemove 的调用频率
+func sensitiveSyncQuotas() []RateQuota {
+	perSecond := []RateLimit{{Limit: 100, Interval: time.Second}}
+	return []RateQuota{
+		{Name: "sensitive_add", Operations: []string{"SensitiveAdd"}, Limits: perSecond},
+		{Name: "sensitive_remove", Operations: []string{"SensitiveRemove"}, Limits: perSecond},
+	}
+}
+
+// SensitiveSync 将服务端敏感词列表同步为 desired
+// 先通过 SensitiveGetList 计算差异，再逐个调用 SensitiveAdd 添加、更新，按每批 50 个调用 SensitiveRemove 删除。
+// 校验失败的敏感词记录在 Invalid 中，调用失败的记录在 Failed 中，不影响其他敏感词的同步。
+// 添加、删除均按每秒 100 次限制调用频率，同时满足 WithRateLimit 开启的限制，超出时等待而不是返回错误；
+// dryRun 为 true 时只返回差异。删除 2 小时后生效
+func (rc *RongCloud) SensitiveSync(desired []SensitiveWord, dryRun bool) (*SensitiveSyncReport, error) {
+	list, err := rc.SensitiveGetList()
+	if err != nil {
+		return nil, err
+	}
+	report := DiffSensitiveWords(list.Words, desired)
+	if dryRun {
+		return report, nil
+	}
+	report.DryRun = false
+
+	limiter := newRateLimiter(RateLimitBlock, sensitiveSyncQuotas())
+	limiter.parent = rc.rateLimiter
+	extra := *rc.rongCloudExtra
+	extra.rateLimiter = limiter
+	c := *rc
+	c.rongCloudExtra = &extra
+
+	for _, w := range append(append([]SensitiveWord(nil), report.Add...), report.Update...) {
+		if err := c.Context().Err(); err != nil {
+			return report, err
+		}
+		var err error
+		if w.Type == "1" {
+			err = c.sensitiveAdd(w.Word, "", SensitiveBlock)
+		} else {
+			err = c.SensitiveAdd(w.Word, w.ReplaceWord, SensitiveReplace)
+		}
+		if err != nil {
+			report.Failed = append(report.Failed, SensitiveWordError{Word: w, Err: err})
+		}
+	}
+	for i := 0; i < len(report.Remove); i += SENSITIVE_REMOVE_BATCH {
+		if err := c.Context().Err(); err != nil {
+			return report, err
+		}
+		end := i + SENSITIVE_REMOVE_BATCH
+		if end > len(report.Remove) {
+			end = len(report.Remove)
+		}
+		batch := report.Remove[i:end]
+		if err := c.SensitiveRemove(batch); err != nil {
+			for _, word := range batch {
+				report.Failed = append(report.Failed, SensitiveWordError{Word: SensitiveWord{Word: word}, Err: err})
+			}
+		}
+	}
+	return report, nil
+}
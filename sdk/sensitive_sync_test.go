@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chinagocoder/rongCloud-sdk/sdk/sdktest"
+)
+
+func TestParseSensitiveWords(t *testing.T) {
+	csvWords, err := ParseSensitiveWordsCSV(strings.NewReader("word,type,replaceWord\nbad,0,***\n spam ,,\nfoo,,bar\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonWords, err := ParseSensitiveWordsJSON(strings.NewReader(`[{"word":"bad","type":0,"replaceWord":"***"},{"word":"spam","type":"1"},{"word":"foo","replaceWord":"bar"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []SensitiveWord{
+		{Word: "bad", Type: "0", ReplaceWord: "***"},
+		{Word: "spam", Type: "1"},
+		{Word: "foo", Type: "0", ReplaceWord: "bar"},
+	}
+	for _, words := range [][]SensitiveWord{csvWords, jsonWords} {
+		if len(words) != len(expect) {
+			t.Fatalf("unexpected words %+v", words)
+		}
+		for i := range expect {
+			if words[i] != expect[i] {
+				t.Errorf("expect %+v, got %+v", expect[i], words[i])
+			}
+		}
+	}
+}
+
+func TestValidateSensitiveWord(t *testing.T) {
+	cases := []struct {
+		word  SensitiveWord
+		valid bool
+	}{
+		{SensitiveWord{Word: "敏感词abc123", Type: "1"}, true},
+		{SensitiveWord{Word: "bad", Type: "0", ReplaceWord: "***"}, true},
+		{SensitiveWord{Word: strings.Repeat("敏", 32), Type: "1"}, true},
+		{SensitiveWord{Word: strings.Repeat("敏", 33), Type: "1"}, false},
+		{SensitiveWord{Word: "bad word", Type: "1"}, false},
+		{SensitiveWord{Word: "bad!", Type: "1"}, false},
+		{SensitiveWord{Word: "bad", Type: "0"}, false},
+		{SensitiveWord{Word: "bad", Type: "2"}, false},
+		{SensitiveWord{Type: "1"}, false},
+	}
+	for _, c := range cases {
+		if err := ValidateSensitiveWord(c.word); (err == nil) != c.valid {
+			t.Errorf("%+v: expect valid %v, got %v", c.word, c.valid, err)
+		}
+	}
+}
+
+func TestRongCloud_SensitiveSync(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL))
+
+	for i := 0; i < 60; i++ {
+		if err := rc.SensitiveAdd("old"+strconv.Itoa(i), "-", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rc.SensitiveAdd("keep", "***", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.SensitiveAdd("change", "***", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	desired := []SensitiveWord{
+		{Word: "keep", Type: "0", ReplaceWord: "***"},
+		{Word: "change", Type: "0", ReplaceWord: "###"},
+		{Word: "new", Type: "1"},
+		{Word: "bad word", Type: "1"},
+		{Word: "old0!", Type: "1"},
+	}
+	report, err := rc.SensitiveSync(desired, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || len(report.Add) != 1 || len(report.Update) != 1 || len(report.Remove) != 60 || len(report.Invalid) != 2 {
+		t.Fatalf("unexpected report add %d update %d remove %d invalid %d", len(report.Add), len(report.Update), len(report.Remove), len(report.Invalid))
+	}
+	if n := len(srv.SensitiveWords()); n != 62 {
+		t.Fatalf("dry run should not change words, got %d", n)
+	}
+
+	srv.Reset()
+	for i := 0; i < 60; i++ {
+		rc.SensitiveAdd("old"+strconv.Itoa(i), "-", 1)
+	}
+	rc.SensitiveAdd("keep", "***", 0)
+	rc.SensitiveAdd("change", "***", 0)
+	before := len(srv.Requests())
+
+	report, err = rc.SensitiveSync(desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.DryRun || len(report.Failed) != 0 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	words := srv.SensitiveWords()
+	if len(words) != 3 || words[0].Word != "change" || words[0].ReplaceWord != "###" || words[2].Word != "new" || words[2].Type != "1" {
+		t.Errorf("unexpected words %+v", words)
+	}
+	var removes int
+	for _, r := range srv.Requests()[before:] {
+		if r.Path == "/sensitiveword/batch/delete.json" {
+			removes++
+			if n := len(r.Form["words"]); n > SENSITIVE_REMOVE_BATCH {
+				t.Errorf("batch of %d words exceeds limit", n)
+			}
+		}
+	}
+	if removes != 2 {
+		t.Errorf("expect 2 remove batches, got %d", removes)
+	}
+}
+
+func TestRongCloud_SensitiveSync_rateLimit(t *testing.T) {
+	srv := sdktest.NewServer()
+	defer srv.Close()
+	// 外层为快速失败模式，同步时超出外层额度的词同样快速失败，记录在 Failed 中
+	quota := RateQuota{
+		Name:       "sensitive_add",
+		Operations: []string{"SensitiveAdd"},
+		Limits:     []RateLimit{{Limit: 1, Interval: time.Hour}},
+	}
+	rc := NewRongCloud(srv.AppKey, srv.AppSecret, WithRongCloudURI(srv.URL), WithRateLimit(RateLimitFailFast, quota))
+
+	desired := []SensitiveWord{{Word: "a", Type: "1"}, {Word: "b", Type: "1"}, {Word: "c", Type: "1"}}
+	report, err := rc.SensitiveSync(desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Failed) != 2 || !errors.Is(report.Failed[0].Err, ErrRateLimited) || len(srv.SensitiveWords()) != 1 {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	for _, r := range srv.Requests() {
+		if _, ok := r.Form["replaceWord"]; ok && r.Path == "/sensitiveword/add.json" {
+			t.Errorf("block word should not send replaceWord, got %v", r.Form)
+		}
+	}
+
+	// 同步自身的额度与外层限制同时生效
+	limiter := newRateLimiter(RateLimitBlock, sensitiveSyncQuotas())
+	limiter.parent = newRateLimiter(RateLimitFailFast, DefaultRateQuotas())
+	extra := *rc.rongCloudExtra
+	extra.rateLimiter = limiter
+	c := *rc
+	c.rongCloudExtra = &extra
+	limiter.reserve(limiter.quotas["SensitiveRemove"], 100)
+	if w := c.RateLimitWait("SensitiveRemove"); w <= 0 {
+		t.Errorf("expect sync quota to apply, got %s", w)
+	}
+}
@@ -6,9 +6,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 const (
@@ -81,8 +78,7 @@ func (rc *RongCloud) UGGroupChannelGet(groupId string, page, limit int) ([]byte,
 		return nil, RCErrorNewV2(1002, "param 'groupId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGGroupChannelGet", http.MethodPost, "/ultragroup/channel/get.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -140,8 +136,7 @@ func (rc *RongCloud) UGHisMsgIdQuery(groupId, busChannel, msgUID, prevNum, lastN
 	var (
 		result = UGHisMsgIdQueryResp{}
 	)
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/hismsg/msgid/query.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGHisMsgIdQuery", http.MethodPost, "/ultragroup/hismsg/msgid/query.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -186,8 +181,7 @@ func (rc *RongCloud) UGHistoryQuery(groupId, busChannel string, startTime, endTi
 	if pageSize > 100 {
 		size = 100
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/hismsg/query.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGHistoryQuery", http.MethodPost, "/ultragroup/hismsg/query.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -232,8 +226,7 @@ func (rc *RongCloud) UGChannelPrivateUserGetResObj(groupId, busChannel, page, pa
 		return result, RCErrorNewV2(1002, "param 'busChannel' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserGetResObj", http.MethodPost, "/ultragroup/channel/private/users/get.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -264,8 +257,7 @@ func (rc *RongCloud) UGChannelPrivateUserGet(groupId, busChannel, page, pageSize
 		return nil, RCErrorNewV2(1002, "param 'busChannel' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserGet", http.MethodPost, "/ultragroup/channel/private/users/get.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -300,8 +292,7 @@ func (rc *RongCloud) UGChannelPrivateUserDelResObj(groupId, busChannel, userIds
 	if len(userIds) == 0 {
 		return result, RCErrorNewV2(1002, "param 'userIds' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/del.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserDelResObj", http.MethodPost, "/ultragroup/channel/private/users/del.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -332,8 +323,7 @@ func (rc *RongCloud) UGChannelPrivateUserDel(groupId, busChannel, userIds string
 	if len(userIds) == 0 {
 		return nil, RCErrorNewV2(1002, "param 'userIds' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/del.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserDel", http.MethodPost, "/ultragroup/channel/private/users/del.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -367,8 +357,7 @@ func (rc *RongCloud) UGChannelPrivateUserAddResObj(groupId, busChannel, userIds
 	if len(userIds) == 0 {
 		return result, RCErrorNewV2(1002, "param 'userIds' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/add.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserAddResObj", http.MethodPost, "/ultragroup/channel/private/users/add.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -400,8 +389,7 @@ func (rc *RongCloud) UGChannelPrivateUserAdd(groupId, busChannel, userIds string
 	if len(userIds) == 0 {
 		return nil, RCErrorNewV2(1002, "param 'userIds' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/private/users/add.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGChannelPrivateUserAdd", http.MethodPost, "/ultragroup/channel/private/users/add.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -418,19 +406,7 @@ func (rc *RongCloud) UGChannelPrivateUserAdd(groupId, busChannel, userIds string
 // response：byte数组
 // *//
 func (rc *RongCloud) UGGroupChannelCreate(groupId, busChannel, t string) ([]byte, error) {
-	if len(groupId) == 0 {
-		return nil, RCErrorNewV2(1002, "param 'groupId' is required")
-	}
-
-	if len(busChannel) == 0 {
-		return nil, RCErrorNewV2(1002, "param 'busChannel' is required")
-	}
-
-	if len(t) == 0 {
-		return nil, RCErrorNewV2(1002, "param 'type' is required")
-	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/create.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGGroupChannelCreate", http.MethodPost, "/ultragroup/channel/create.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -467,8 +443,7 @@ func (rc *RongCloud) UGGroupChannelChangeResObj(groupId, busChannel, t string) (
 	if len(t) == 0 {
 		return result, RCErrorNewV2(1002, "param 'type' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/type/change.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGGroupChannelChangeResObj", http.MethodPost, "/ultragroup/channel/type/change.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -505,8 +480,7 @@ func (rc *RongCloud) UGGroupChannelChange(groupId, busChannel, t string) ([]byte
 	if len(t) == 0 {
 		return nil, RCErrorNewV2(1002, "param 'type' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/type/change.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGGroupChannelChange", http.MethodPost, "/ultragroup/channel/type/change.json")
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -530,9 +504,8 @@ func (rc *RongCloud) UGGroupCreate(userId, groupId, groupName string) (err error
 		return RCErrorNewV2(1002, "param 'groupName' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups", rc.rongCloudURI)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := "/v2/ultragroups"
+	req := rc.newRequest("UGGroupCreate", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -558,9 +531,8 @@ func (rc *RongCloud) UGGroupDismiss(groupId string) (err error, requestId string
 		return RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s", rc.rongCloudURI, groupId)
-	req := httplib.Delete(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s", groupId)
+	req := rc.newRequest("UGGroupDismiss", http.MethodDelete, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -579,9 +551,8 @@ func (rc *RongCloud) UGGroupJoin(userId, groupId string) (err error, requestId s
 		return RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/users/%s", rc.rongCloudURI, groupId, userId)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/users/%s", groupId, userId)
+	req := rc.newRequest("UGGroupJoin", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -600,9 +571,8 @@ func (rc *RongCloud) UGGroupQuit(userId, groupId string) (err error, requestId s
 		return RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/users/%s", rc.rongCloudURI, groupId, userId)
-	req := httplib.Delete(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/users/%s", groupId, userId)
+	req := rc.newRequest("UGGroupQuit", http.MethodDelete, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -620,9 +590,8 @@ func (rc *RongCloud) UGGroupUpdate(groupId, groupName string) (err error, reques
 		return RCErrorNewV2(1002, "param 'groupName' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s", rc.rongCloudURI, groupId)
-	req := httplib.Put(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s", groupId)
+	req := rc.newRequest("UGGroupUpdate", http.MethodPut, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -646,9 +615,8 @@ func (rc *RongCloud) UGQueryUserGroups(userId string, page, size int) (groups []
 		return nil, RCErrorNewV2(1002, "param 'userId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/users/%s/groups", rc.rongCloudURI, userId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/users/%s/groups", userId)
+	req := rc.newRequest("UGQueryUserGroups", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	req.Param("page", strconv.Itoa(page))
@@ -687,9 +655,8 @@ func (rc *RongCloud) UGQueryGroupUsers(groupId string, page, size int) (users []
 		return nil, RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/users", rc.rongCloudURI, groupId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/users", groupId)
+	req := rc.newRequest("UGQueryGroupUsers", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	req.Param("page", strconv.Itoa(page))
@@ -731,9 +698,8 @@ func (rc *RongCloud) UGGroupSend(msg UGMessage) (err error, requestId string) {
 		return RCErrorNewV2(1002, "Paramer 'ToGroupIds' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/message/ultragroup/send", rc.rongCloudURI)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := "/v2/message/ultragroup/send"
+	req := rc.newRequest("UGGroupSend", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -758,9 +724,8 @@ func (rc *RongCloud) UGGroupMuteMembersAdd(groupId string, userIds []string) (er
 		return RCErrorNewV2(1002, "Paramer 'userIds' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/muted-users", rc.rongCloudURI, groupId)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/muted-users", groupId)
+	req := rc.newRequest("UGGroupMuteMembersAdd", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -788,9 +753,8 @@ func (rc *RongCloud) UGGroupMuteMembersRemove(groupId string, userIds []string)
 		return RCErrorNewV2(1002, "Paramer 'userIds' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/muted-users", rc.rongCloudURI, groupId)
-	req := httplib.Delete(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/muted-users", groupId)
+	req := rc.newRequest("UGGroupMuteMembersRemove", http.MethodDelete, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -814,9 +778,8 @@ func (rc *RongCloud) UGGroupMuteMembersGetList(groupId string) (users []UGUserIn
 		return nil, RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/muted-users", rc.rongCloudURI, groupId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/muted-users", groupId)
+	req := rc.newRequest("UGGroupMuteMembersGetList", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -852,9 +815,8 @@ func (rc *RongCloud) UGGroupMuted(groupId string, status bool) (err error, reque
 		return RCErrorNewV2(1002, "Paramer 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/muted-status", rc.rongCloudURI, groupId)
-	req := httplib.Put(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/muted-status", groupId)
+	req := rc.newRequest("UGGroupMuted", http.MethodPut, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -878,9 +840,8 @@ func (rc *RongCloud) UGGroupMutedQuery(groupId string) (status bool, err error,
 		return status, RCErrorNewV2(1002, "Paramer 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/muted-status", rc.rongCloudURI, groupId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/muted-status", groupId)
+	req := rc.newRequest("UGGroupMutedQuery", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -914,9 +875,8 @@ func (rc *RongCloud) UGGroupMutedWhitelistAdd(groupId string, userIds []string)
 		return RCErrorNewV2(1002, "Paramer 'userIds' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/allowed-users", rc.rongCloudURI, groupId)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/allowed-users", groupId)
+	req := rc.newRequest("UGGroupMutedWhitelistAdd", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -944,9 +904,8 @@ func (rc *RongCloud) UGGroupMutedWhitelistRemove(groupId string, userIds []strin
 		return RCErrorNewV2(1002, "Paramer 'userIds' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/allowed-users", rc.rongCloudURI, groupId)
-	req := httplib.Delete(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/allowed-users", groupId)
+	req := rc.newRequest("UGGroupMutedWhitelistRemove", http.MethodDelete, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// json body
@@ -970,9 +929,8 @@ func (rc *RongCloud) UGGroupMutedWhitelistQuery(groupId string) (users []UGUserI
 		return nil, RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/allowed-users", rc.rongCloudURI, groupId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/allowed-users", groupId)
+	req := rc.newRequest("UGGroupMutedWhitelistQuery", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -1011,9 +969,8 @@ func (rc *RongCloud) UGChannelCreate(groupId, channelId string) (err error, requ
 		return RCErrorNewV2(1002, "param 'channelId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/channels", rc.rongCloudURI)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := "/v2/ultragroups/channels"
+	req := rc.newRequest("UGChannelCreate", http.MethodPost, path)
 	requestId = rc.fillHeaderV2(req)
 
 	body := map[string]interface{}{
@@ -1042,9 +999,8 @@ func (rc *RongCloud) UGChannelDelete(groupId, channelId string) (err error, requ
 		return RCErrorNewV2(1002, "param 'channelId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/channels/%s", rc.rongCloudURI, groupId, channelId)
-	req := httplib.Delete(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/channels/%s", groupId, channelId)
+	req := rc.newRequest("UGChannelDelete", http.MethodDelete, path)
 	requestId = rc.fillHeaderV2(req)
 
 	// http
@@ -1059,9 +1015,8 @@ func (rc *RongCloud) UGChannelQuery(groupId string, page, size int) (channels []
 		return nil, RCErrorNewV2(1002, "param 'groupId' is required"), ""
 	}
 
-	url := fmt.Sprintf("%s/v2/ultragroups/%s/channels", rc.rongCloudURI, groupId)
-	req := httplib.Get(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/v2/ultragroups/%s/channels", groupId)
+	req := rc.newRequest("UGChannelQuery", http.MethodGet, path)
 	requestId = rc.fillHeaderV2(req)
 
 	req.Param("page", strconv.Itoa(page))
@@ -1121,8 +1076,7 @@ func (rc *RongCloud) UGMessageExpansionSet(groupId, userId, msgUID, busChannel s
 		return err
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/message/expansion/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageExpansionSet", http.MethodPost, "/ultragroup/message/expansion/set."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1164,8 +1118,7 @@ func (rc *RongCloud) UGMessageExpansionDelete(groupId, userId, msgUID, busChanne
 		return err
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/message/expansion/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageExpansionDelete", http.MethodPost, "/ultragroup/message/expansion/delete."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1200,8 +1153,7 @@ func (rc *RongCloud) UGMessageExpansionQuery(groupId, msgUID, busChannel string)
 		return nil, RCErrorNewV2(1002, "param 'msgUID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/message/expansion/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMessageExpansionQuery", http.MethodPost, "/ultragroup/message/expansion/query."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("msgUID", msgUID)
@@ -1226,7 +1178,7 @@ func (rc *RongCloud) UGMessageExpansionQuery(groupId, msgUID, busChannel string)
 	}
 
 	if resp.Code != 200 {
-		return nil, fmt.Errorf("Response error. code: %d", resp.Code)
+		return nil, RCErrorNewV2(resp.Code, "Response error")
 	}
 
 	var data []UGMessageExpansionItem
@@ -1259,90 +1211,35 @@ type PushExt struct {
 // UGMessagePublish 超级群消息发送
 // 文档：https://doc.rongcloud.cn/imserver/server/v1/message/msgsend/ultragroup
 func (rc *RongCloud) UGMessagePublish(fromUserId, objectName, content, pushContent, pushData, isPersisted, isCounted, isMentioned, contentAvailable, busChannel, extraContent string, expansion, unreadCountFlag bool, pushExt *PushExt, toGroupIds ...string) error {
-	if len(fromUserId) == 0 {
-		return RCErrorNewV2(1002, "param 'fromUserId' is required")
-	}
-
-	if len(objectName) == 0 {
-		return RCErrorNewV2(1002, "param 'objectName' is required")
-	}
-
-	if len(content) == 0 {
-		return RCErrorNewV2(1002, "param 'content' is required")
-	}
-
-	if groupLen := len(toGroupIds); groupLen <= 0 || groupLen > 3 {
-		return RCErrorNewV2(1002, "invalid 'toGroupIds'")
-	}
-
-	req := httplib.Post(rc.rongCloudURI + "/message/ultragroup/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
-
-	body := map[string]interface{}{
-		"fromUserId": fromUserId,
-		"toGroupIds": toGroupIds,
-		"objectName": objectName,
-		"content":    content,
-		"expansion":  expansion,
-	}
-
-	if pushContent != "" {
-		body["pushContent"] = pushContent
-	}
-
-	if pushData != "" {
-		body["pushData"] = pushData
+	encPushExt, err := encodePushExt(pushExt)
+	if err != nil {
+		return err
 	}
 
+	m := rc.NewMessage(MessageTargetUltraGroup).
+		From(fromUserId).
+		To(toGroupIds...).
+		ObjectName(objectName).
+		Content(rawMsg(content)).
+		PushContent(pushContent).
+		PushData(pushData).
+		PushExt(encPushExt).
+		Counted(isCounted != "0").
+		BusChannel(busChannel).
+		Expansion(expansion, extraContent).
+		UnreadCountFlag(unreadCountFlag)
+	// 为空时不发送，与原有请求保持一致
 	if isPersisted != "" {
-		body["isPersisted"] = isPersisted
+		m.Persisted(isPersisted != "0")
 	}
-
 	if isMentioned != "" {
-		body["isMentioned"] = isMentioned
-	}
-
-	if isMentioned != "1" {
-		body["unreadCountFlag"] = unreadCountFlag
+		m.Mentioned(isMentioned == "1")
 	}
-
 	if contentAvailable != "" {
-		body["contentAvailable"] = contentAvailable
-	}
-
-	if busChannel != "" {
-		body["busChannel"] = busChannel
-	}
-
-	if extraContent != "" {
-		body["extraContent"] = extraContent
-	}
-
-	body["isCounted"] = isCounted
-	if len(isCounted) == 0 || isCounted != "1" && isCounted != "0" {
-		body["isCounted"] = "1"
-	}
-
-	if pushExt != nil {
-		encPushExt, e := json.Marshal(pushExt)
-		if e != nil {
-			return e
-		}
-		body["pushExt"] = string(encPushExt)
-	}
-
-	var err error
-	req, err = req.JSONBody(body)
-	if err != nil {
-		return err
-	}
-
-	if _, err = rc.doV2(req); err != nil {
-		return err
+		m.ContentAvailable(contentAvailable == "1")
 	}
-
-	return nil
+	_, err = m.Send()
+	return err
 }
 
 // UGMemberExists 查询用户是否在超级群中
@@ -1355,8 +1252,7 @@ func (rc *RongCloud) UGMemberExists(groupId, userId string) (bool, error) {
 		return false, RCErrorNewV2(1002, "param 'userId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/member/exist." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UGMemberExists", http.MethodPost, "/ultragroup/member/exist."+ReqType)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1377,7 +1273,7 @@ func (rc *RongCloud) UGMemberExists(groupId, userId string) (bool, error) {
 	}
 
 	if resp.Code != http.StatusOK {
-		return false, fmt.Errorf("Response error. code: %d", resp.Code)
+		return false, RCErrorNewV2(resp.Code, "Response error")
 	}
 
 	return resp.Status, nil
@@ -1396,7 +1292,7 @@ func (rc *RongCloud) UGNotDisturbSet(groupId string, unPushLevel int, busChannel
 
 	var err error
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/notdisturb/set.json")
+	req := rc.newRequest("UGNotDisturbSet", http.MethodPost, "/ultragroup/notdisturb/set.json")
 
 	req.Param("groupId", groupId)
 	req.Param("unpushLevel", strconv.Itoa(unPushLevel))
@@ -1405,8 +1301,6 @@ func (rc *RongCloud) UGNotDisturbSet(groupId string, unPushLevel int, busChannel
 		req.Param("busChannel", busChannel)
 	}
 
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-
 	rc.fillHeader(req)
 
 	data, err := rc.doV2(req)
@@ -1422,7 +1316,7 @@ func (rc *RongCloud) UGNotDisturbSet(groupId string, unPushLevel int, busChannel
 	}
 
 	if resp.Code != http.StatusOK {
-		return fmt.Errorf("Response error. code: %d", resp.Code)
+		return RCErrorNewV2(resp.Code, "Response error")
 	}
 
 	return nil
@@ -1441,7 +1335,7 @@ func (rc *RongCloud) UGNotDisturbGet(groupId, busChannel string) (*UGNotDisturbG
 
 	var err error
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/notdisturb/get.json")
+	req := rc.newRequest("UGNotDisturbGet", http.MethodPost, "/ultragroup/notdisturb/get.json")
 
 	req.Param("groupId", groupId)
 
@@ -1449,8 +1343,6 @@ func (rc *RongCloud) UGNotDisturbGet(groupId, busChannel string) (*UGNotDisturbG
 		req.Param("busChannel", busChannel)
 	}
 
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-
 	rc.fillHeader(req)
 
 	data, err := rc.doV2(req)
@@ -1469,7 +1361,7 @@ func (rc *RongCloud) UGNotDisturbGet(groupId, busChannel string) (*UGNotDisturbG
 	}
 
 	if resp.Code != http.StatusOK {
-		return nil, fmt.Errorf("Response error. code: %d", resp.Code)
+		return nil, RCErrorNewV2(resp.Code, "Response error")
 	}
 
 	return &UGNotDisturbGetResponses{
@@ -1493,9 +1385,8 @@ func (rc *RongCloud) UltraGroupCreate(userId, groupId, groupName string) error {
 		return RCErrorNew(1002, "param 'groupName' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/create.json")
+	req := rc.newRequest("UltraGroupCreate", http.MethodPost, "/ultragroup/create.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("userId", userId)
@@ -1516,7 +1407,7 @@ func (rc *RongCloud) UltraGroupCreate(userId, groupId, groupName string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1528,9 +1419,8 @@ func (rc *RongCloud) UltraGroupDis(groupId string) error {
 		return RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/dis.json")
+	req := rc.newRequest("UltraGroupDis", http.MethodPost, "/ultragroup/dis.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1549,7 +1439,7 @@ func (rc *RongCloud) UltraGroupDis(groupId string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1565,9 +1455,8 @@ func (rc *RongCloud) UltraGroupJoin(userId, groupId string) error {
 		return RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/join.json")
+	req := rc.newRequest("UltraGroupJoin", http.MethodPost, "/ultragroup/join.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("userId", userId)
@@ -1587,7 +1476,7 @@ func (rc *RongCloud) UltraGroupJoin(userId, groupId string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1603,9 +1492,8 @@ func (rc *RongCloud) UltraGroupQuit(userId, groupId string) error {
 		return RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/quit.json")
+	req := rc.newRequest("UltraGroupQuit", http.MethodPost, "/ultragroup/quit.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("userId", userId)
@@ -1625,7 +1513,7 @@ func (rc *RongCloud) UltraGroupQuit(userId, groupId string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1641,9 +1529,8 @@ func (rc *RongCloud) UltraGroupRefresh(groupId, groupName string) error {
 		return RCErrorNew(1002, "param 'groupName' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/refresh.json")
+	req := rc.newRequest("UltraGroupRefresh", http.MethodPost, "/ultragroup/refresh.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1663,7 +1550,7 @@ func (rc *RongCloud) UltraGroupRefresh(groupId, groupName string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1683,9 +1570,8 @@ func (rc *RongCloud) UltraGroupUserBannedAdd(groupId, busChannel string, userIds
 		return RCErrorNew(1002, "param 'userIds' is too long")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/userbanned/add.json")
+	req := rc.newRequest("UltraGroupUserBannedAdd", http.MethodPost, "/ultragroup/userbanned/add.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1709,7 +1595,7 @@ func (rc *RongCloud) UltraGroupUserBannedAdd(groupId, busChannel string, userIds
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1729,9 +1615,8 @@ func (rc *RongCloud) UltraGroupUserBannedDel(groupId, busChannel string, userIds
 		return RCErrorNew(1002, "param 'userIds' is too long")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/userbanned/del.json")
+	req := rc.newRequest("UltraGroupUserBannedDel", http.MethodPost, "/ultragroup/userbanned/del.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1755,7 +1640,7 @@ func (rc *RongCloud) UltraGroupUserBannedDel(groupId, busChannel string, userIds
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1771,9 +1656,8 @@ func (rc *RongCloud) UltraGroupUserBannedGet(groupId, busChannel string, page, p
 		return nil, RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/userbanned/get.json")
+	req := rc.newRequest("UltraGroupUserBannedGet", http.MethodPost, "/ultragroup/userbanned/get.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1805,7 +1689,7 @@ func (rc *RongCloud) UltraGroupUserBannedGet(groupId, busChannel string, page, p
 	}
 
 	if data.Code != 200 {
-		return nil, fmt.Errorf("response error. code: %d", data.Code)
+		return nil, RCErrorNew(data.Code, "Response error")
 	}
 
 	return data.Users, nil
@@ -1817,9 +1701,8 @@ func (rc *RongCloud) UltraGroupGlobalBannedSet(groupId, busChannel string, statu
 		return RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/globalbanned/set.json")
+	req := rc.newRequest("UltraGroupGlobalBannedSet", http.MethodPost, "/ultragroup/globalbanned/set.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1843,7 +1726,7 @@ func (rc *RongCloud) UltraGroupGlobalBannedSet(groupId, busChannel string, statu
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1855,9 +1738,8 @@ func (rc *RongCloud) UltraGroupGlobalBannedGet(groupId, busChannel string) (bool
 		return false, RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/globalbanned/get.json")
+	req := rc.newRequest("UltraGroupGlobalBannedGet", http.MethodPost, "/ultragroup/globalbanned/get.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1881,7 +1763,7 @@ func (rc *RongCloud) UltraGroupGlobalBannedGet(groupId, busChannel string) (bool
 	}
 
 	if data.Code != 200 {
-		return false, fmt.Errorf("response error. code: %d", data.Code)
+		return false, RCErrorNew(data.Code, "Response error")
 	}
 
 	return data.Status, nil
@@ -1901,9 +1783,8 @@ func (rc *RongCloud) UltraGroupBannedWhiteListAdd(groupId, busChannel string, us
 		return RCErrorNew(1002, "param 'userIds' is too long")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/banned/whitelist/add.json")
+	req := rc.newRequest("UltraGroupBannedWhiteListAdd", http.MethodPost, "/ultragroup/banned/whitelist/add.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1927,7 +1808,7 @@ func (rc *RongCloud) UltraGroupBannedWhiteListAdd(groupId, busChannel string, us
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1947,9 +1828,8 @@ func (rc *RongCloud) UltraGroupBannedWhiteListDel(groupId, busChannel string, us
 		return RCErrorNew(1002, "param 'userIds' is too long")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/banned/whitelist/del.json")
+	req := rc.newRequest("UltraGroupBannedWhiteListDel", http.MethodPost, "/ultragroup/banned/whitelist/del.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -1973,7 +1853,7 @@ func (rc *RongCloud) UltraGroupBannedWhiteListDel(groupId, busChannel string, us
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -1989,9 +1869,8 @@ func (rc *RongCloud) UltraGroupBannedWhiteListGet(groupId, busChannel string, pa
 		return nil, RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/banned/whitelist/get.json")
+	req := rc.newRequest("UltraGroupBannedWhiteListGet", http.MethodPost, "/ultragroup/banned/whitelist/get.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2023,7 +1902,7 @@ func (rc *RongCloud) UltraGroupBannedWhiteListGet(groupId, busChannel string, pa
 	}
 
 	if data.Code != 200 {
-		return nil, fmt.Errorf("response error. code: %d", data.Code)
+		return nil, RCErrorNew(data.Code, "Response error")
 	}
 
 	return data.Users, nil
@@ -2039,9 +1918,8 @@ func (rc *RongCloud) UltraGroupChannelCreate(groupId, busChannel string) error {
 		return RCErrorNew(1002, "param 'busChannel' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/create.json")
+	req := rc.newRequest("UltraGroupChannelCreate", http.MethodPost, "/ultragroup/channel/create.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2061,7 +1939,7 @@ func (rc *RongCloud) UltraGroupChannelCreate(groupId, busChannel string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -2077,9 +1955,8 @@ func (rc *RongCloud) UltraGroupChannelDel(groupId, busChannel string) error {
 		return RCErrorNew(1002, "param 'busChannel' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/del.json")
+	req := rc.newRequest("UltraGroupChannelDel", http.MethodPost, "/ultragroup/channel/del.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2099,7 +1976,7 @@ func (rc *RongCloud) UltraGroupChannelDel(groupId, busChannel string) error {
 	}
 
 	if data.Code != 200 {
-		return fmt.Errorf("response error. code: %d", data.Code)
+		return RCErrorNew(data.Code, "Response error")
 	}
 
 	return nil
@@ -2118,9 +1995,8 @@ func (rc *RongCloud) UltraGroupChannelGet(groupId string, page, limit int) ([]Ul
 		return nil, RCErrorNew(1002, "param 'groupId' is empty")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/ultragroup/channel/get.json")
+	req := rc.newRequest("UltraGroupChannelGet", http.MethodPost, "/ultragroup/channel/get.json")
 
-	req.SetTimeout(rc.timeout*time.Second, rc.timeout*time.Second)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2148,7 +2024,7 @@ func (rc *RongCloud) UltraGroupChannelGet(groupId string, page, limit int) ([]Ul
 	}
 
 	if data.Code != 200 {
-		return nil, fmt.Errorf("response error. code: %d", data.Code)
+		return nil, RCErrorNew(data.Code, "Response error")
 	}
 
 	return data.Channels, nil
@@ -2164,9 +2040,8 @@ func (rc *RongCloud) UGUserGroupAdd(groupId string, userGroups []UGUserGroupInfo
 		return RCErrorNew(1002, "param 'userGroups' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/add.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/add.%s", ReqType)
+	req := rc.newRequest("UGUserGroupAdd", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	body := map[string]interface{}{
@@ -2195,9 +2070,8 @@ func (rc *RongCloud) UGUserGroupDelete(groupId string, userGroupIds []string) (e
 		return RCErrorNew(1002, "param 'userGroupIds' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/del.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/del.%s", ReqType)
+	req := rc.newRequest("UGUserGroupDelete", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2216,9 +2090,8 @@ func (rc *RongCloud) UGUserGroupQuery(groupId string, page, pageSize int) (userG
 		return nil, RCErrorNew(1002, "param 'groupId' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/query.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/query.%s", ReqType)
+	req := rc.newRequest("UGUserGroupQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2257,9 +2130,8 @@ func (rc *RongCloud) UGUserGroupUserAdd(groupId, userGroupId string, userIds []s
 		return RCErrorNew(1002, "param 'userIds' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/user/add.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/user/add.%s", ReqType)
+	req := rc.newRequest("UGUserGroupUserAdd", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2287,9 +2159,8 @@ func (rc *RongCloud) UGUserGroupUserDelete(groupId, userGroupId string, userIds
 		return RCErrorNew(1002, "param 'userIds' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/user/del.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/user/del.%s", ReqType)
+	req := rc.newRequest("UGUserGroupUserDelete", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2313,9 +2184,8 @@ func (rc *RongCloud) UGUserUserGroupQuery(groupId, userId string, page, pageSize
 		return nil, RCErrorNew(1002, "param 'userId' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/user/usergroup/query.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/user/usergroup/query.%s", ReqType)
+	req := rc.newRequest("UGUserUserGroupQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2355,9 +2225,8 @@ func (rc *RongCloud) UGChannelUserGroupBind(groupId, busChannel string, userGrou
 		return RCErrorNew(1002, "param 'userGroupIds' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/channel/usergroup/bind.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/channel/usergroup/bind.%s", ReqType)
+	req := rc.newRequest("UGChannelUserGroupBind", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2385,9 +2254,8 @@ func (rc *RongCloud) UGChannelUserGroupUnbind(groupId, busChannel string, userGr
 		return RCErrorNew(1002, "param 'userGroupIds' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/channel/usergroup/unbind.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/channel/usergroup/unbind.%s", ReqType)
+	req := rc.newRequest("UGChannelUserGroupUnbind", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2411,9 +2279,8 @@ func (rc *RongCloud) UGChannelUserGroupQuery(groupId, busChannel string, page, p
 		return nil, RCErrorNew(1002, "param 'busChannel' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/channel/usergroup/query.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/channel/usergroup/query.%s", ReqType)
+	req := rc.newRequest("UGChannelUserGroupQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2449,9 +2316,8 @@ func (rc *RongCloud) UGUserGroupChannelQuery(groupId, userGroupId string, page,
 		return nil, RCErrorNew(1002, "param 'userGroupId' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/usergroup/channel/query.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/usergroup/channel/query.%s", ReqType)
+	req := rc.newRequest("UGUserGroupChannelQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -2487,9 +2353,8 @@ func (rc *RongCloud) UGUserChannelQuery(groupId, userId string, page, pageSize i
 		return nil, RCErrorNew(1002, "param 'userId' is required")
 	}
 
-	url := fmt.Sprintf("%s/ultragroup/user/channel/query.%s", rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	path := fmt.Sprintf("/ultragroup/user/channel/query.%s", ReqType)
+	req := rc.newRequest("UGUserChannelQuery", http.MethodPost, path)
 	rc.fillHeader(req)
 
 	req.Param("groupId", groupId)
@@ -11,11 +11,9 @@ package sdk
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // User 用户信息 返回信息
@@ -68,8 +66,7 @@ func (rc *RongCloud) UserBlockPushPeriodDelete(userId string) error {
 	if len(userId) == 0 {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/blockPushPeriod/delete.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserBlockPushPeriodDelete", http.MethodPost, "/user/blockPushPeriod/delete.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	_, err := rc.do(req)
@@ -100,8 +97,7 @@ func (rc *RongCloud) UserBlockPushPeriodGet(userId string) (PushPeriodGet, error
 	if len(userId) == 0 {
 		return data, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/blockPushPeriod/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserBlockPushPeriodGet", http.MethodPost, "/user/blockPushPeriod/get.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	res, err := rc.do(req)
@@ -135,8 +131,7 @@ func (rc *RongCloud) UserBlockPushPeriodSet(userId, startTime, period, level str
 		return RCErrorNew(1002, "Paramer 'period' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blockPushPeriod/set.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserBlockPushPeriodSet", http.MethodPost, "/user/blockPushPeriod/set.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("startTime", startTime)
@@ -177,8 +172,7 @@ func (rc *RongCloud) UserTokenExpireResObj(userId string, t int64) (UserTokenExp
 		return result, RCErrorNew(1002, "Paramer 'time' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/token/expire.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserTokenExpireResObj", http.MethodPost, "/user/token/expire.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("time", fmt.Sprintf("%v", t))
@@ -209,8 +203,7 @@ func (rc *RongCloud) UserTokenExpire(userId string, t int64) ([]byte, error) {
 		return nil, RCErrorNew(1002, "Paramer 'time' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/token/expire.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserTokenExpire", http.MethodPost, "/user/token/expire.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("time", fmt.Sprintf("%v", t))
@@ -258,8 +251,7 @@ func (rc *RongCloud) UserRemarksGetResObj(userId string, page, size int) (UserRe
 	if len(userId) == 0 {
 		return result, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/remarks/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserRemarksGetResObj", http.MethodPost, "/user/remarks/get.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("page", strconv.Itoa(page))
@@ -288,8 +280,7 @@ func (rc *RongCloud) UserRemarksGet(userId string, page, size int) ([]byte, erro
 	if len(userId) == 0 {
 		return nil, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/remarks/get.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserRemarksGet", http.MethodPost, "/user/remarks/get.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("page", strconv.Itoa(page))
@@ -315,8 +306,7 @@ func (rc *RongCloud) UserRemarksDel(userId, targetId string) error {
 	if len(targetId) == 0 {
 		return RCErrorNew(1002, "Paramer 'targetId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/remarks/del.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserRemarksDel", http.MethodPost, "/user/remarks/del.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("targetId", targetId)
@@ -353,8 +343,7 @@ func (rc *RongCloud) UserRemarksSet(userId string, remarks []UserRemark) error {
 	if err != nil {
 		return RCErrorNew(1002, "Marshal 'remarks' err")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/remarks/set.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserRemarksSet", http.MethodPost, "/user/remarks/set.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("remarks", string(remarkList))
@@ -396,8 +385,7 @@ func (rc *RongCloud) UserChatFbQueryListResObj(num, offset int, t string) (UserC
 		return result, RCErrorNew(1002, "Paramer 'type' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/chat/fb/querylist.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserChatFbQueryListResObj", http.MethodPost, "/user/chat/fb/querylist.json")
 	rc.fillHeader(req)
 	req.Param("num", strconv.Itoa(num))
 	req.Param("offset", strconv.Itoa(offset))
@@ -428,8 +416,7 @@ func (rc *RongCloud) UserChatFbQueryList(num, offset int, t string) ([]byte, err
 		return nil, RCErrorNew(1002, "Paramer 'type' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/chat/fb/querylist.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserChatFbQueryList", http.MethodPost, "/user/chat/fb/querylist.json")
 	rc.fillHeader(req)
 	req.Param("num", strconv.Itoa(num))
 	req.Param("offset", strconv.Itoa(offset))
@@ -455,8 +442,7 @@ func (rc *RongCloud) UserChatFbSet(userId string, state int, t string) error {
 		return RCErrorNew(1002, "Paramer 'type' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/chat/fb/set.json")
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserChatFbSet", http.MethodPost, "/user/chat/fb/set.json")
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	req.Param("state", fmt.Sprintf("%v", state))
@@ -489,8 +475,7 @@ func (rc *RongCloud) AddWhiteList(userId string, whiteList []string) error {
 		return RCErrorNew(1002, "Length of paramer 'whiteList' must less than 20")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("AddWhiteList", http.MethodPost, "/user/whitelist/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	for _, v := range whiteList {
@@ -525,8 +510,7 @@ func (rc *RongCloud) RemoveWhiteList(userId string, whiteList []string) error {
 		return RCErrorNew(1002, "Length of paramer 'whiteList' must less than 20")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("RemoveWhiteList", http.MethodPost, "/user/whitelist/remove."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 	for _, v := range whiteList {
@@ -552,8 +536,7 @@ func (rc *RongCloud) QueryWhiteList(userId string) (WhiteList, error) {
 		return WhiteList{}, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("QueryWhiteList", http.MethodPost, "/user/whitelist/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userId)
 
@@ -579,15 +562,7 @@ func (rc *RongCloud) QueryWhiteList(userId string) (WhiteList, error) {
 *@return User, error
  */
 func (rc *RongCloud) UserRegister(userID, name, portraitURI string) (User, error) {
-	if userID == "" {
-		return User{}, RCErrorNew(1002, "Paramer 'userID' is required")
-	}
-	if name == "" {
-		return User{}, RCErrorNew(1002, "Paramer 'name' is required")
-	}
-
-	req := httplib.Post(rc.rongCloudURI + "/user/getToken." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserRegister", http.MethodPost, "/user/getToken."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userID)
 	req.Param("name", name)
@@ -617,12 +592,7 @@ func (rc *RongCloud) UserRegister(userID, name, portraitURI string) (User, error
 *@return error
  */
 func (rc *RongCloud) UserUpdate(userID, name, portraitURI string) error {
-	if userID == "" {
-		return RCErrorNew(1002, "Paramer 'userID' is required")
-	}
-
-	req := httplib.Post(rc.rongCloudURI + "/user/refresh." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserUpdate", http.MethodPost, "/user/refresh."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userID)
 	req.Param("name", name)
@@ -650,8 +620,7 @@ func (rc *RongCloud) BlockAdd(id string, minute uint64) error {
 		return RCErrorNew(20004, "封禁时间不正确, 当前传入为 , 正确范围 1 - 1 * 30 * 24 * 60 分钟")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/block." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("BlockAdd", http.MethodPost, "/user/block."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", id)
 	req.Param("minute", strconv.FormatUint(minute, 10))
@@ -673,8 +642,7 @@ func (rc *RongCloud) BlockRemove(id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/unblock." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("BlockRemove", http.MethodPost, "/user/unblock."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", id)
 
@@ -690,8 +658,7 @@ func (rc *RongCloud) BlockRemove(id string) error {
 *@return QueryBlockUserResult error
  */
 func (rc *RongCloud) BlockGetList() (BlockListResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/user/block/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("BlockGetList", http.MethodPost, "/user/block/query."+ReqType)
 	rc.fillHeader(req)
 
 	resp, err := rc.do(req)
@@ -716,15 +683,32 @@ func (rc *RongCloud) BlockGetList() (BlockListResult, error) {
 *@return error
  */
 func (rc *RongCloud) BlacklistAdd(id string, blacklist []string) error {
+	_, err := rc.BlacklistAddResObj(id, blacklist)
+	return err
+}
+
+// BlacklistAddResObj : 同 BlacklistAdd，返回每一批的调用结果，未拆分时只有一批
+/*
+*@return []BatchResult error
+ */
+func (rc *RongCloud) BlacklistAddResObj(id string, blacklist []string) ([]BatchResult, error) {
 	if id == "" {
-		return RCErrorNew(1002, "Paramer 'id' is required")
+		return nil, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 	if len(blacklist) == 0 {
-		return RCErrorNew(1002, "Paramer 'blacklist' is required")
+		return nil, RCErrorNew(1002, "Paramer 'blacklist' is required")
 	}
+	if rc.shouldFanOut(len(blacklist), BLACKLIST_ADD_MAX_USERS) {
+		return rc.fanOut("BlacklistAdd", blacklist, BLACKLIST_ADD_MAX_USERS, func(batch []string) error {
+			return rc.blacklistAdd(id, batch)
+		})
+	}
+	err := rc.blacklistAdd(id, blacklist)
+	return singleBatch(blacklist, err), err
+}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+func (rc *RongCloud) blacklistAdd(id string, blacklist []string) error {
+	req := rc.newRequest("BlacklistAdd", http.MethodPost, "/user/blacklist/add."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", id)
 	for _, v := range blacklist {
@@ -753,8 +737,7 @@ func (rc *RongCloud) BlacklistRemove(id string, blacklist []string) error {
 		return RCErrorNew(1002, "Paramer 'blacklist' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("BlacklistRemove", http.MethodPost, "/user/blacklist/remove."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", id)
 	for _, v := range blacklist {
@@ -779,8 +762,7 @@ func (rc *RongCloud) BlacklistGet(id string) (BlacklistResult, error) {
 		return BlacklistResult{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("BlacklistGet", http.MethodPost, "/user/blacklist/query."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", id)
 
@@ -808,8 +790,7 @@ func (rc *RongCloud) OnlineStatusCheck(userID string) (int, error) {
 		return -1, RCErrorNew(1002, "Paramer 'userID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/checkOnline." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("OnlineStatusCheck", http.MethodPost, "/user/checkOnline."+ReqType)
 	rc.fillHeader(req)
 	req.Param("userId", userID)
 
@@ -833,8 +814,7 @@ func (rc *RongCloud) OnlineStatusCheck(userID string) (int, error) {
 *@return error
  */
 func (rc *RongCloud) TagSet(tag Tag) error {
-	req := httplib.Post(rc.rongCloudURI + "/user/tag/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("TagSet", http.MethodPost, "/user/tag/set."+ReqType)
 	rc.fillHeader(req)
 	req, err := req.JSONBody(tag)
 	if err != nil {
@@ -856,8 +836,7 @@ func (rc *RongCloud) TagSet(tag Tag) error {
 *@return error
  */
 func (rc *RongCloud) TagBatchSet(tagBatch TagBatch) error {
-	req := httplib.Post(rc.rongCloudURI + "/user/tag/batch/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("TagBatchSet", http.MethodPost, "/user/tag/batch/set."+ReqType)
 	rc.fillHeader(req)
 	req, err := req.JSONBody(tagBatch)
 	if err != nil {
@@ -879,8 +858,7 @@ func (rc *RongCloud) TagBatchSet(tagBatch TagBatch) error {
 *@return error
  */
 func (rc *RongCloud) TagGet(userIds []string) (TagResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/user/tags/get." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("TagGet", http.MethodPost, "/user/tags/get."+ReqType)
 	rc.fillHeader(req)
 	for _, v := range userIds {
 		req.Param("userIds", v)
@@ -911,8 +889,7 @@ type UserDeactivateResponse struct {
 // official doc https://doc.rongcloud.cn/imserver/server/v1/user/deactivate
 // 发起注销后，服务端会在 15 分钟内通过回调通知注销结果。 https://doc.rongcloud.cn/imserver/server/v1/user/callback-deactivation
 func (rc *RongCloud) UserDeactivate(userIds []string) (*UserDeactivateResponse, error) {
-	req := httplib.Post(fmt.Sprintf("%s%s", rc.rongCloudURI, "/user/deactivate.json"))
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserDeactivate", http.MethodPost, "/user/deactivate.json")
 	rc.fillHeader(req)
 	req.Param("userId", strings.Join(userIds, ","))
 	body, err := rc.doV2(req)
@@ -938,8 +915,7 @@ type UserDeactivateQueryResponse struct {
 // @return string, error
 // official doc https://doc.rongcloud.cn/imserver/server/v1/user/query-deactivated-list
 func (rc *RongCloud) UserDeactivateQuery(pageNo, pageSize int) (*UserDeactivateQueryResponse, error) {
-	req := httplib.Post(fmt.Sprintf("%s/%s", rc.rongCloudURI, "/user/deactivate/query.json"))
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserDeactivateQuery", http.MethodPost, "/user/deactivate/query.json")
 	rc.fillHeader(req)
 	req.Param("pageNo", strconv.Itoa(pageNo))
 	req.Param("pageSize", strconv.Itoa(pageSize))
@@ -966,8 +942,7 @@ type UserReactivateResponse struct {
 // official doc https://doc.rongcloud.cn/imserver/server/v1/user/reactivate
 // 重新激活用户请通过(https://doc.rongcloud.cn/imserver/server/v1/user/callback-deactivation)接口获取重新激活结果。重复调用此接口不会报错。
 func (rc *RongCloud) UserReactivate(userIds []string) (*UserReactivateResponse, error) {
-	req := httplib.Post(fmt.Sprintf("%s%s", rc.rongCloudURI, "/user/reactivate.json"))
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
+	req := rc.newRequest("UserReactivate", http.MethodPost, "/user/reactivate.json")
 	rc.fillHeader(req)
 	req.Param("userId", strings.Join(userIds, ","))
 	body, err := rc.doV2(req)
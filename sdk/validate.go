@@ -0,0 +1,265 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MESSAGE_MAX_SIZE 单条消息内容最大 128k
+	MESSAGE_MAX_SIZE = 128 * 1024
+	// CHANNEL_ID_MAX_LENGTH 频道 Id 最长 20 个字符，支持英文字母、数字组合
+	CHANNEL_ID_MAX_LENGTH = 20
+	// USER_ID_MAX_LENGTH 用户 ID 最大长度 64 字节
+	USER_ID_MAX_LENGTH = 64
+	// NAME_MAX_LENGTH 用户名称最大长度 128 字节
+	NAME_MAX_LENGTH = 128
+)
+
+// Violation 一项未通过本地检查的参数
+type Violation struct {
+	Field   string // 参数名
+	Message string // 错误信息
+}
+
+// ValidationError 本地参数检查失败，包含同一次检查中发现的全部参数错误
+// 发送请求前统一检查 paramLimits 中的长度限制，以及 requiredParams 中列出的接口的必填参数；MessageRequest 在 Send 时检查。
+// 其他接口的必填参数仍在各自方法中检查，遇到第一个错误即返回。
+// 以 *Error 返回，业务码为 1002，可通过 errors.As 获取
+//
+//	var verr *sdk.ValidationError
+//	if errors.As(err, &verr) {
+//		for _, v := range verr.Violations {
+//			// v.Field, v.Message
+//		}
+//	}
+type ValidationError struct {
+	Operation  string // 调用的方法名
+	Violations []Violation
+}
+
+// Error 获取错误信息，多项错误以分号分隔
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validator 收集参数检查结果，在发送请求前一次返回全部错误
+type validator struct {
+	apiVersion int
+	operation  string
+	violations []Violation
+}
+
+func newValidator(operation string, apiVersion int) *validator {
+	return &validator{apiVersion: apiVersion, operation: operation}
+}
+
+// add 添加参数错误，text 为参数名之后的错误说明
+func (v *validator) add(field, text string) {
+	prefix := "Paramer '"
+	if v.apiVersion == 2 {
+		prefix = "param '"
+	}
+	v.addMessage(field, prefix+field+"' "+text)
+}
+
+// addError 添加其他方法返回的参数错误
+func (v *validator) addError(field string, err error) {
+	var e *Error
+	if errors.As(err, &e) {
+		if ve, ok := e.Err.(*ValidationError); ok {
+			for _, violation := range ve.Violations {
+				v.addMessage(violation.Field, violation.Message)
+			}
+			return
+		}
+		v.addMessage(field, e.Message)
+		return
+	}
+	v.addMessage(field, err.Error())
+}
+
+func (v *validator) addMessage(field, message string) {
+	for _, violation := range v.violations {
+		if violation.Field == field && violation.Message == message {
+			return
+		}
+	}
+	v.violations = append(v.violations, Violation{Field: field, Message: message})
+}
+
+// required 必填参数
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, "is required")
+	}
+}
+
+// userID 用户 ID 最大 64 字节，多个用户 ID 以逗号分隔时分别检查
+func (v *validator) userID(field, value string) {
+	for _, id := range strings.Split(value, ",") {
+		if len(id) > USER_ID_MAX_LENGTH {
+			v.add(field, "must not exceed "+strconv.Itoa(USER_ID_MAX_LENGTH)+" bytes")
+		}
+	}
+}
+
+// name 用户名称最大 128 字节
+func (v *validator) name(field, value string) {
+	if len(value) > NAME_MAX_LENGTH {
+		v.add(field, "must not exceed "+strconv.Itoa(NAME_MAX_LENGTH)+" bytes")
+	}
+}
+
+// channelID 频道 Id 最长 20 个字符，只能为英文字母、数字
+func (v *validator) channelID(field, value string) {
+	if len(value) > CHANNEL_ID_MAX_LENGTH || !isAlphanumeric(value) {
+		v.add(field, "must be at most "+strconv.Itoa(CHANNEL_ID_MAX_LENGTH)+" letters or digits")
+	}
+}
+
+// content 消息内容最大 128k
+func (v *validator) content(field, value string) {
+	if len(value) > MESSAGE_MAX_SIZE {
+		v.add(field, "must not exceed "+strconv.Itoa(MESSAGE_MAX_SIZE/1024)+"KB")
+	}
+}
+
+// err 存在参数错误时返回 *Error，原始错误为 *ValidationError
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	ve := &ValidationError{Operation: v.operation, Violations: v.violations}
+	return &Error{APIVersion: v.apiVersion, Code: 1002, Message: ve.Error(), Err: ve}
+}
+
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// paramLimit 一个参数的限制，operations 为空时对所有接口生效
+type paramLimit struct {
+	check      func(v *validator, field, value string)
+	operations []string
+}
+
+// appliesTo 限制是否对 operation 生效
+func (l paramLimit) appliesTo(operation string) bool {
+	if len(l.operations) == 0 {
+		return true
+	}
+	for _, op := range l.operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// paramLimits 文档中给出的参数限制，按表单参数名或 json 请求体的字段名检查，json 字符串数组逐项检查
+// 用户 ID、消息内容、频道 Id 的限制对所有接口相同，用户名称只在文档注明的接口检查
+var paramLimits = map[string]paramLimit{
+	"userId":      {check: (*validator).userID},
+	"fromUserId":  {check: (*validator).userID},
+	"toUserId":    {check: (*validator).userID},
+	"blackUserId": {check: (*validator).userID},
+	"whiteUserId": {check: (*validator).userID},
+	"userIds":     {check: (*validator).userID},
+	"toUserIds":   {check: (*validator).userID},
+	"content":     {check: (*validator).content},
+	"busChannel":  {check: (*validator).channelID},
+	"name":        {check: (*validator).name, operations: []string{"UserRegister", "UserUpdate"}},
+}
+
+// requiredParams 各接口的必填参数，按表单参数名或 json 请求体的字段名检查，未设置或为空时报错
+var requiredParams = map[string][]string{
+	"UserRegister":         {"userId", "name"},
+	"UserUpdate":           {"userId"},
+	"UGGroupChannelCreate": {"groupId", "busChannel", "type"},
+}
+
+// validateRequest 发送请求前按 requiredParams 和 paramLimits 检查参数，返回全部错误
+func validateRequest(req *request) error {
+	v := newValidator(req.operation, req.version)
+	var body map[string]interface{}
+	if len(req.body) > 0 && strings.HasPrefix(req.header.Get("Content-Type"), "application/json") {
+		if json.Unmarshal(req.body, &body) != nil {
+			body = nil
+		}
+	}
+
+	for _, field := range requiredParams[req.operation] {
+		if !hasParam(req, body, field) {
+			v.add(field, "is required")
+		}
+	}
+
+	keys := make([]string, 0, len(req.params))
+	for k := range req.params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if limit, ok := paramLimits[k]; ok && limit.appliesTo(req.operation) {
+			for _, value := range req.params[k] {
+				limit.check(v, k, value)
+			}
+		}
+	}
+
+	keys = keys[:0]
+	for k := range body {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		limit, ok := paramLimits[k]
+		if !ok || !limit.appliesTo(req.operation) {
+			continue
+		}
+		switch value := body[k].(type) {
+		case string:
+			limit.check(v, k, value)
+		case []interface{}:
+			for _, item := range value {
+				if s, isString := item.(string); isString {
+					limit.check(v, k, s)
+				}
+			}
+		}
+	}
+	return v.err()
+}
+
+// hasParam 表单参数或 json 字段是否已设置且不为空
+func hasParam(req *request, body map[string]interface{}, field string) bool {
+	for _, value := range req.params[field] {
+		if value != "" {
+			return true
+		}
+	}
+	switch value := body[field].(type) {
+	case nil:
+		return false
+	case string:
+		return value != ""
+	case []interface{}:
+		return len(value) > 0
+	default:
+		return true
+	}
+}
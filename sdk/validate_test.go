@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUserRegister_validate(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+
+	_, err := rc.UserRegister("", strings.Repeat("n", NAME_MAX_LENGTH+1), "")
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidParam) {
+		t.Fatalf("expect *ValidationError, got %v", err)
+	}
+	if e := err.(*Error); e.APIVersion != 1 || e.Code != 1002 {
+		t.Errorf("unexpected error %+v", e)
+	}
+	if verr.Operation != "UserRegister" || len(verr.Violations) != 2 ||
+		verr.Violations[0].Message != "Paramer 'userId' is required" || verr.Violations[1].Field != "name" {
+		t.Errorf("unexpected violations %+v", verr)
+	}
+	if err := rc.UserUpdate(strings.Repeat("u", USER_ID_MAX_LENGTH+1), strings.Repeat("n", NAME_MAX_LENGTH+1), ""); !errors.As(err, &verr) || len(verr.Violations) != 2 {
+		t.Errorf("expect 2 violations, got %v", err)
+	}
+	if _, err := rc.UGGroupChannelCreate("", "channel_01", ""); !errors.As(err, &verr) || len(verr.Violations) != 3 || verr.Violations[1].Field != "type" {
+		t.Errorf("expect 3 violations, got %v", err)
+	}
+	if len(invs) != 0 {
+		t.Errorf("invalid request should not be sent, got %d requests", len(invs))
+	}
+
+	if _, err := rc.UserRegister(strings.Repeat("u", USER_ID_MAX_LENGTH), strings.Repeat("n", NAME_MAX_LENGTH), ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 1 {
+		t.Errorf("expect 1 request, got %d", len(invs))
+	}
+}
+
+func TestValidateRequest_scope(t *testing.T) {
+	rc := NewRongCloud("key", "secret")
+	long := strings.Repeat("n", NAME_MAX_LENGTH+1)
+	req := rc.newRequest("UserRegister", http.MethodPost, "/user/getToken.json").Param("name", long)
+	if err := validateRequest(req); err == nil {
+		t.Error("expect name limit for UserRegister")
+	}
+	req = rc.newRequest("GroupCreate", http.MethodPost, "/group/create.json").Param("name", long)
+	if err := validateRequest(req); err != nil {
+		t.Errorf("name limit should only apply to user APIs, got %v", err)
+	}
+}
+
+func TestValidateRequest_jsonBody(t *testing.T) {
+	rc := NewRongCloud("key", "secret")
+	req, err := rc.newRequest("", http.MethodPost, "/v2/test").JSONBody(map[string]interface{}{
+		"busChannel": "channel_01",
+		"userId":     "u01," + strings.Repeat("u", USER_ID_MAX_LENGTH+1),
+		"toUserIds":  []string{"u02", strings.Repeat("u", USER_ID_MAX_LENGTH+1)},
+		"count":      1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.version = 2
+	err = validateRequest(req)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Violations) != 3 || verr.Violations[1].Field != "toUserIds" {
+		t.Fatalf("expect 3 violations, got %v", err)
+	}
+	if verr.Violations[0].Message != "param 'busChannel' must be at most 20 letters or digits" {
+		t.Errorf("unexpected message %q", verr.Violations[0].Message)
+	}
+	if e := err.(*Error); e.APIVersion != 2 {
+		t.Errorf("expect v2 error, got %+v", e)
+	}
+}
+
+func TestMessageRequest_validateAll(t *testing.T) {
+	var invs []*Invocation
+	rc := NewRongCloud("key", "secret", captureRequests(&invs))
+	long := strings.Repeat("u", USER_ID_MAX_LENGTH+1)
+
+	_, err := rc.NewMessage(MessageTargetPrivate).From(long).To("u01", long, long).
+		Content(&TXTMsg{Content: strings.Repeat("a", MESSAGE_MAX_SIZE)}).BusChannel("ch-01").Send()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expect *ValidationError, got %v", err)
+	}
+	fields := make([]string, len(verr.Violations))
+	for i, v := range verr.Violations {
+		fields[i] = v.Field
+	}
+	if got := strings.Join(fields, ","); got != "fromUserId,toUserId,busChannel,content" {
+		t.Errorf("unexpected violations %s: %v", got, err)
+	}
+
+	_, err = rc.NewMessage(MessageTargetUltraGroup).To("ug01").Content(&GIFMsg{}).Send()
+	if !errors.As(err, &verr) || len(verr.Violations) != 2 || err.(*Error).APIVersion != 2 {
+		t.Errorf("expect sender and content violations, got %v", err)
+	}
+	if len(invs) != 0 {
+		t.Errorf("invalid messages should not be sent, got %d requests", len(invs))
+	}
+}
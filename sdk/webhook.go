@@ -0,0 +1,174 @@
+package sdk
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DEFAULT_WEBHOOK_WINDOW 回调时间戳允许的最大偏差
+	DEFAULT_WEBHOOK_WINDOW = 5 * time.Minute
+)
+
+var (
+	// ErrWebhookSignature 回调签名错误或缺少签名参数
+	ErrWebhookSignature = errors.New("rongcloud: invalid webhook signature")
+	// ErrWebhookExpired 回调时间戳超出允许范围
+	ErrWebhookExpired = errors.New("rongcloud: webhook timestamp expired")
+	// ErrWebhookReplay 回调 nonce 重复使用
+	ErrWebhookReplay = errors.New("rongcloud: webhook nonce replayed")
+)
+
+// NonceStore 记录已经使用过的 nonce，用于检测重放，多实例部署时可以使用 Redis 等共享存储实现
+type NonceStore interface {
+	// Seen 记录 nonce，nonce 已经存在时返回 true。expire 之后记录可以清除
+	Seen(nonce string, expire time.Time) bool
+}
+
+// memoryNonceStore 内存中的 NonceStore
+type memoryNonceStore struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	lastPurge time.Time
+}
+
+// NewMemoryNonceStore 创建内存中的 NonceStore，只适用于单实例部署
+func NewMemoryNonceStore() NonceStore {
+	return &memoryNonceStore{nonces: map[string]time.Time{}}
+}
+
+func (s *memoryNonceStore) Seen(nonce string, expire time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.lastPurge) > time.Minute {
+		for k, v := range s.nonces {
+			if now.After(v) {
+				delete(s.nonces, k)
+			}
+		}
+		s.lastPurge = now
+	}
+	if v, ok := s.nonces[nonce]; ok && !now.After(v) {
+		return true
+	}
+	s.nonces[nonce] = expire
+	return false
+}
+
+// WebhookOption WebhookVerifier 的配置项
+type WebhookOption func(*WebhookVerifier)
+
+// WithWebhookWindow 设置时间戳允许的最大偏差，默认 5 分钟，小于等于 0 时不校验时间戳
+func WithWebhookWindow(window time.Duration) WebhookOption {
+	return func(v *WebhookVerifier) {
+		v.window = window
+	}
+}
+
+// WithNonceStore 设置 nonce 存储，默认使用 NewMemoryNonceStore()，为 nil 时不检测重放
+func WithNonceStore(store NonceStore) WebhookOption {
+	return func(v *WebhookVerifier) {
+		v.store = store
+	}
+}
+
+// WebhookVerifier 校验融云回调（消息路由、在线状态、消息回调等）的签名
+// 融云在回调地址上附加 appKey、nonce、timestamp、signature 参数，
+// signature 为 App Secret、nonce、timestamp 拼接后的 SHA1 哈希，与调用 Server API 的签名方式一致
+type WebhookVerifier struct {
+	appKey    string
+	appSecret string
+	window    time.Duration
+	store     NonceStore
+	now       func() time.Time
+}
+
+// NewWebhookVerifier 创建回调签名校验
+func NewWebhookVerifier(appKey, appSecret string, options ...WebhookOption) *WebhookVerifier {
+	v := &WebhookVerifier{
+		appKey:    appKey,
+		appSecret: appSecret,
+		window:    DEFAULT_WEBHOOK_WINDOW,
+		store:     NewMemoryNonceStore(),
+		now:       time.Now,
+	}
+	for _, option := range options {
+		option(v)
+	}
+	return v
+}
+
+// WebhookVerifier 使用当前 App-Key、App-Secret 创建回调签名校验
+func (rc *RongCloud) WebhookVerifier(options ...WebhookOption) *WebhookVerifier {
+	return NewWebhookVerifier(rc.appKey, rc.appSecret, options...)
+}
+
+// Verify 校验请求签名，参数优先从 url 中读取，其次从同名请求头中读取
+// 返回的错误可以通过 errors.Is 判断为 ErrWebhookSignature、ErrWebhookExpired 或 ErrWebhookReplay
+func (v *WebhookVerifier) Verify(r *http.Request) error {
+	appKey := webhookParam(r, "appKey", "App-Key")
+	nonce := webhookParam(r, "nonce", "Nonce")
+	timestamp := webhookParam(r, "timestamp", "Timestamp")
+	signature := webhookParam(r, "signature", "Signature")
+	if nonce == "" || timestamp == "" || signature == "" {
+		return fmt.Errorf("%w: nonce, timestamp and signature are required", ErrWebhookSignature)
+	}
+	if appKey != "" && appKey != v.appKey {
+		return fmt.Errorf("%w: unexpected appKey %s", ErrWebhookSignature, appKey)
+	}
+	expected := fmt.Sprintf("%x", sha1.Sum([]byte(v.appSecret+nonce+timestamp)))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return ErrWebhookSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid timestamp %s", ErrWebhookSignature, timestamp)
+	}
+	sent := webhookTime(ts)
+	expire := sent.Add(DEFAULT_WEBHOOK_WINDOW)
+	if v.window > 0 {
+		if d := v.now().Sub(sent); d > v.window || d < -v.window {
+			return fmt.Errorf("%w: timestamp %s", ErrWebhookExpired, timestamp)
+		}
+		expire = sent.Add(v.window)
+	}
+	if v.store != nil && v.store.Seen(nonce+":"+timestamp, expire) {
+		return fmt.Errorf("%w: nonce %s", ErrWebhookReplay, nonce)
+	}
+	return nil
+}
+
+// Middleware 校验签名的 net/http 中间件，校验失败时返回 401，不调用 next
+func (v *WebhookVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.Verify(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// webhookParam 从 url 参数或请求头中读取签名参数
+func webhookParam(r *http.Request, query, header string) string {
+	if v := r.URL.Query().Get(query); v != "" {
+		return v
+	}
+	return r.Header.Get(header)
+}
+
+// webhookTime 解析时间戳，回调使用毫秒时间戳，同时兼容秒级时间戳
+func webhookTime(ts int64) time.Time {
+	if ts > 1e12 {
+		return time.Unix(0, ts*int64(time.Millisecond))
+	}
+	return time.Unix(ts, 0)
+}
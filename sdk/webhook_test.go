@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func signedWebhookURL(appKey, appSecret, nonce string, ts time.Time) string {
+	timestamp := strconv.FormatInt(ts.UnixNano()/int64(time.Millisecond), 10)
+	q := url.Values{
+		"appKey":    {appKey},
+		"nonce":     {nonce},
+		"timestamp": {timestamp},
+		"signature": {fmt.Sprintf("%x", sha1.Sum([]byte(appSecret+nonce+timestamp)))},
+	}
+	return "/callback?" + q.Encode()
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	rc := NewRongCloud("key", "secret")
+	v := rc.WebhookVerifier(WithWebhookWindow(time.Minute))
+	now := time.Now()
+
+	cases := []struct {
+		name   string
+		target string
+		err    error
+	}{
+		{"valid", signedWebhookURL("key", "secret", "n1", now), nil},
+		{"replay", signedWebhookURL("key", "secret", "n1", now), ErrWebhookReplay},
+		{"wrong secret", signedWebhookURL("key", "other", "n2", now), ErrWebhookSignature},
+		{"wrong app key", signedWebhookURL("other", "secret", "n3", now), ErrWebhookSignature},
+		{"stale", signedWebhookURL("key", "secret", "n4", now.Add(-2*time.Minute)), ErrWebhookExpired},
+		{"future", signedWebhookURL("key", "secret", "n5", now.Add(2*time.Minute)), ErrWebhookExpired},
+		{"missing", "/callback?nonce=n6", ErrWebhookSignature},
+	}
+	for _, c := range cases {
+		err := v.Verify(httptest.NewRequest(http.MethodPost, c.target, nil))
+		if c.err == nil && err != nil || c.err != nil && !errors.Is(err, c.err) {
+			t.Errorf("%s: expect %v, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestWebhookVerifier_Middleware(t *testing.T) {
+	var called int
+	handler := NewWebhookVerifier("key", "secret", WithNonceStore(nil)).Middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called++
+		}))
+
+	target := signedWebhookURL("key", "secret", "n1", time.Now())
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("expect 200 without nonce store, got %d", rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, signedWebhookURL("key", "bad", "n2", time.Now()), nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expect 401, got %d", rec.Code)
+	}
+	if called != 2 {
+		t.Errorf("expect next called twice, got %d", called)
+	}
+}
+
+func TestMemoryNonceStore(t *testing.T) {
+	s := NewMemoryNonceStore()
+	if s.Seen("a", time.Now().Add(time.Minute)) {
+		t.Error("first nonce should not be seen")
+	}
+	if !s.Seen("a", time.Now().Add(time.Minute)) {
+		t.Error("nonce should be seen")
+	}
+	if s.Seen("b", time.Now().Add(-time.Second)) || s.Seen("b", time.Now().Add(time.Minute)) {
+		t.Error("expired nonce should be accepted again")
+	}
+}